@@ -0,0 +1,84 @@
+package zhkit
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 排序键的分隔字节
+const (
+	collationLevelEnd    byte = 0x00 // 各比较级别之间
+	collationSyllableEnd byte = 0x01 // 每个汉字的拼音之后，使 ma（马）排在 mai（买）之前
+	collationSymbol      byte = 0xFE // 标点等符号之前，使其排在所有字母之后（UTF-8 中不会出现该字节）
+)
+
+// Collator 按拼音排序的比较器
+// 依次比较：拼音字母（汉字按拼音、拉丁字母不区分大小写），声调，码位
+// 多音字使用词组读音或第一个读音；空白排在最前，数字排在字母之前，
+// 没有拼音数据的字母（如 é、Я）按码位排在拉丁字母之后，标点等其他符号按码位排在所有字母之后
+type Collator struct {
+	chinese *Chinese
+	isName  bool // 是否按姓名排序（姓氏使用姓氏读音）
+}
+
+// NewCollator 创建按拼音排序的比较器
+func (c *Chinese) NewCollator() *Collator {
+	return &Collator{chinese: c}
+}
+
+// SortKey 生成排序键，排序键按字节比较的结果与 Compare 相同，可以存入数据库索引
+func (col *Collator) SortKey(text string) []byte {
+	units := col.chinese.pinyinUnits(text, &PinyinOptions{SplitNonChinese: true, Polyphone: PolyphoneFirst, IsName: col.isName})
+
+	primary := make([]byte, 0, len(text)*2)
+	tones := make([]byte, 0, len(units))
+	for _, unit := range units {
+		if unit.pinyins == nil {
+			for _, r := range unit.text {
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r) {
+					primary = append(primary, collationSymbol)
+				}
+				primary = utf8.AppendRune(primary, unicode.ToLower(r))
+			}
+			continue
+		}
+
+		base, tone := col.chinese.splitTone(unit.pinyins[0])
+		primary = append(primary, strings.ToLower(base)...)
+		primary = append(primary, collationSyllableEnd)
+		tones = append(tones, byte('0'+tone))
+	}
+
+	key := make([]byte, 0, len(primary)+len(tones)+len(text)+2)
+	key = append(key, primary...)
+	key = append(key, collationLevelEnd)
+	key = append(key, tones...)
+	key = append(key, collationLevelEnd)
+	return append(key, text...)
+}
+
+// Compare 比较两个字符串，a 排在 b 之前时返回 -1，相同时返回 0，否则返回 1
+func (col *Collator) Compare(a, b string) int {
+	return bytes.Compare(col.SortKey(a), col.SortKey(b))
+}
+
+// SortStrings 按拼音排序字符串切片
+func (col *Collator) SortStrings(texts []string) {
+	keys := make(map[string][]byte, len(texts))
+	for _, text := range texts {
+		if _, exists := keys[text]; !exists {
+			keys[text] = col.SortKey(text)
+		}
+	}
+	slices.SortFunc(texts, func(a, b string) int {
+		return bytes.Compare(keys[a], keys[b])
+	})
+}
+
+// NewCollator 全局函数：创建按拼音排序的比较器
+func NewCollator() *Collator {
+	return defaultChinese.NewCollator()
+}
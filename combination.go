@@ -0,0 +1,178 @@
+package zhkit
+
+import (
+	"container/heap"
+	"iter"
+	"slices"
+)
+
+// DefaultCombinationLimit 读音组合的默认数量上限
+const DefaultCombinationLimit = 1000
+
+// CombinationOptions 读音组合枚举选项
+type CombinationOptions struct {
+	PinyinOptions      // 转换选项，Mode 只能指定一种转换模式
+	Limit         int  // 最多返回的组合数，<= 0 时为 DefaultCombinationLimit
+	Ranked        bool // 是否按可能性从高到低返回，否则按读音顺序逐位枚举
+}
+
+// PinyinCombinations 枚举文本的读音组合
+// 每个组合与 ToPinyinTokens 的单元一一对应，非中文单元为原文
+// 词组命中的字以词组读音为首选，其余读音按读音表顺序排列；
+// Ranked 为 true 时按各字所选读音的排位之和从小到大返回
+func (c *Chinese) PinyinCombinations(text string, options *CombinationOptions) (iter.Seq[[]string], error) {
+	if options == nil {
+		options = &CombinationOptions{}
+	}
+
+	pinyinOptions := c.normalizePinyinOptions(&options.PinyinOptions)
+	converter, err := findPinyinConverter(pinyinOptions.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := options.Limit
+	if limit <= 0 {
+		limit = DefaultCombinationLimit
+	}
+
+	candidates := c.combinationCandidates(text, pinyinOptions, converter)
+	if len(candidates) == 0 {
+		return func(func([]string) bool) {}, nil
+	}
+	if options.Ranked {
+		return rankedCombinations(candidates, nil, limit), nil
+	}
+	return orderedCombinations(candidates, limit), nil
+}
+
+// combinationCandidates 返回每个转换单元的候选读音（已按转换模式去重）
+func (c *Chinese) combinationCandidates(text string, options *PinyinOptions, converter pinyinConverter) [][]string {
+	units := c.pinyinUnits(text, options)
+	candidates := make([][]string, 0, len(units))
+
+	for _, unit := range units {
+		if unit.pinyins == nil {
+			candidates = append(candidates, []string{unit.text})
+			continue
+		}
+
+		pinyins := unit.pinyins
+		if unit.phrase && options.Polyphone == PolyphoneAll {
+			// 词组读音优先，其余读音作为备选
+			for _, py := range c.pinyinData[[]rune(unit.text)[0]] {
+				if py != unit.pinyins[0] {
+					pinyins = append(pinyins[:len(pinyins):len(pinyins)], py)
+				}
+			}
+		}
+
+		candidates = append(candidates, c.convertReadings(pinyins, converter, options))
+	}
+
+	return candidates
+}
+
+// combinationOf 按下标取出一个组合
+func combinationOf(candidates [][]string, indexes []int) []string {
+	combination := make([]string, len(candidates))
+	for i, index := range indexes {
+		combination[i] = candidates[i][index]
+	}
+	return combination
+}
+
+// orderedCombinations 按读音顺序逐位枚举（最后一个单元变化最快）
+func orderedCombinations(candidates [][]string, limit int) iter.Seq[[]string] {
+	return func(yield func([]string) bool) {
+		indexes := make([]int, len(candidates))
+		for count := 0; count < limit; count++ {
+			if !yield(combinationOf(candidates, indexes)) {
+				return
+			}
+
+			// 进位
+			i := len(indexes) - 1
+			for ; i >= 0; i-- {
+				indexes[i]++
+				if indexes[i] < len(candidates[i]) {
+					break
+				}
+				indexes[i] = 0
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}
+}
+
+// combinationState 排序枚举中的一个组合
+type combinationState struct {
+	indexes []int
+	level   int // 各单元候选等级的最大值
+	cost    int // 各单元读音排位之和
+	pos     int // 只允许递增 pos 及之后的下标，保证每个组合只生成一次
+}
+
+// combinationHeap 按 level、cost 排序的最小堆，都相同时按下标字典序
+type combinationHeap []combinationState
+
+func (h combinationHeap) Len() int { return len(h) }
+func (h combinationHeap) Less(i, j int) bool {
+	if h[i].level != h[j].level {
+		return h[i].level < h[j].level
+	}
+	if h[i].cost != h[j].cost {
+		return h[i].cost < h[j].cost
+	}
+	return slices.Compare(h[i].indexes, h[j].indexes) < 0
+}
+func (h combinationHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *combinationHeap) Push(x any)   { *h = append(*h, x.(combinationState)) }
+func (h *combinationHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// rankedCombinations 按读音排位之和从小到大枚举
+// levels 为各单元候选的等级（nil 表示都为 0），组合的等级取各单元的最大值，等级低的组合先枚举
+func rankedCombinations(candidates [][]string, levels [][]int, limit int) iter.Seq[[]string] {
+	levelOf := func(i, j int) int {
+		if levels == nil {
+			return 0
+		}
+		return levels[i][j]
+	}
+
+	return func(yield func([]string) bool) {
+		start := combinationState{indexes: make([]int, len(candidates))}
+		for i := range candidates {
+			start.level = max(start.level, levelOf(i, 0))
+		}
+		h := &combinationHeap{start}
+		for count := 0; count < limit && h.Len() > 0; count++ {
+			state := heap.Pop(h).(combinationState)
+			if !yield(combinationOf(candidates, state.indexes)) {
+				return
+			}
+
+			for i := state.pos; i < len(candidates); i++ {
+				if state.indexes[i]+1 >= len(candidates[i]) {
+					continue
+				}
+				next := slices.Clone(state.indexes)
+				next[i]++
+				level := max(state.level, levelOf(i, next[i]))
+				heap.Push(h, combinationState{indexes: next, level: level, cost: state.cost + 1, pos: i})
+			}
+		}
+	}
+}
+
+// PinyinCombinations 全局函数：枚举文本的读音组合
+func PinyinCombinations(text string, options *CombinationOptions) (iter.Seq[[]string], error) {
+	return defaultChinese.PinyinCombinations(text, options)
+}
@@ -83,6 +83,7 @@ func (c *Chinese) LoadSimplifiedTraditionalData(dataPath string) error {
 
 // loadPinyinFromCharData 从CharData加载拼音数据
 func (c *Chinese) loadPinyinFromCharData(data map[string]*CharData) error {
+	c.resetIndexes()
 	for char, charData := range data {
 		if len(charData.Pinyin) > 0 {
 			runes := []rune(char)
@@ -146,6 +147,7 @@ func (c *Chinese) loadCharsDataJSON(filePath string) error {
 	if err != nil {
 		return fmt.Errorf("读取charsData.json文件失败: %v", err)
 	}
+	c.resetIndexes()
 
 	// 尝试解析为不同的JSON格式
 	// 格式1: {"字符": {"pinyin": ["拼音"], "simplified": ["简体"], "traditional": ["繁体"]}}
@@ -262,6 +264,7 @@ func (c *Chinese) parseCharsDataFormat3(data map[string]string) error {
 
 // parseCharsDataFormat4 解析格式4的charsData.json (原PHP项目格式)
 // 格式: {"字符": ["拼音", "简体", "繁体", 数字1, 数字2]}
+// 数字1、数字2 为常用字标记，两者之和作为拼音反查的常用程度
 func (c *Chinese) parseCharsDataFormat4(data map[string][]interface{}) error {
 	for char, charData := range data {
 		runes := []rune(char)
@@ -301,27 +304,69 @@ func (c *Chinese) parseCharsDataFormat4(data map[string][]interface{}) error {
 				}
 			}
 		}
+
+		// 解析常用字标记 (第四、五个元素)
+		weight := 0
+		for _, flag := range charData[3:] {
+			if value, ok := flag.(float64); ok {
+				weight += int(value)
+			}
+		}
+		if weight > 0 {
+			c.charWeight[charRune] = weight
+		}
 	}
 	return nil
 }
 
 // LoadPinyinSplitData 加载拼音分词数据
+// 读取 pinyinData.json 中 split 表的 relation 音节前缀树（兼容原PHP项目）
 func (c *Chinese) LoadPinyinSplitData(dataPath string) error {
-	// 尝试加载JSON格式的拼音分词数据
-	filePath := filepath.Join(dataPath, "pinyin_split.json")
+	filePath := filepath.Join(dataPath, "pinyinData.json")
 	file, err := os.Open(filePath)
 	if err != nil {
 		return fmt.Errorf("无法打开拼音分词数据文件: %v", err)
 	}
 	defer file.Close()
 
+	var data struct {
+		Split struct {
+			Relation map[string]interface{} `json:"relation"`
+		} `json:"split"`
+	}
 	decoder := json.NewDecoder(file)
-	err = decoder.Decode(&c.pinyinSplitData)
-	if err != nil {
+	if err := decoder.Decode(&data); err != nil {
 		return fmt.Errorf("解析拼音分词数据失败: %v", err)
 	}
+	if data.Split.Relation == nil {
+		return fmt.Errorf("拼音分词数据文件中缺少 split.relation 表")
+	}
 
-	return nil
+	return c.parsePinyinSplitData(data.Split.Relation)
+}
+
+// LoadSoundData 加载声调数据
+// 读取 pinyinData.json 中的 sound 表（兼容原PHP项目）
+func (c *Chinese) LoadSoundData(dataPath string) error {
+	filePath := filepath.Join(dataPath, "pinyinData.json")
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("无法打开声调数据文件: %v", err)
+	}
+	defer file.Close()
+
+	var data map[string]interface{}
+	decoder := json.NewDecoder(file)
+	if err := decoder.Decode(&data); err != nil {
+		return fmt.Errorf("解析声调数据失败: %v", err)
+	}
+
+	soundData, ok := data["sound"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("声调数据文件中缺少 sound 表")
+	}
+
+	return c.parseSoundData(soundData)
 }
 
 // SaveDataToJSON 保存数据到JSON文件
@@ -366,9 +411,9 @@ func (c *Chinese) SaveDataToJSON(dataPath string) error {
 		return fmt.Errorf("保存繁体数据失败: %v", err)
 	}
 
-	// 保存拼音分词数据
+	// 保存拼音分词数据（音节表）
 	pinyinSplitFile := filepath.Join(dataPath, "pinyin_split_export.json")
-	if err := c.saveJSONFile(pinyinSplitFile, c.pinyinSplitData); err != nil {
+	if err := c.saveJSONFile(pinyinSplitFile, c.syllableTrie.syllables()); err != nil {
 		return fmt.Errorf("保存拼音分词数据失败: %v", err)
 	}
 
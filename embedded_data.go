@@ -15,6 +15,18 @@ var embeddedCharsData []byte
 //go:embed data/pinyinData.json
 var embeddedPinyinData []byte
 
+//go:embed data/phrasesData.json
+var embeddedPhrasesData []byte
+
+//go:embed data/wordsData.json
+var embeddedWordsData []byte
+
+//go:embed data/surnamesData.json
+var embeddedSurnamesData []byte
+
+//go:embed data/frequencyData.json
+var embeddedFrequencyData []byte
+
 // loadEmbeddedData 加载嵌入的数据
 func (c *Chinese) loadEmbeddedData() error {
 	// 加载字符数据
@@ -27,6 +39,31 @@ func (c *Chinese) loadEmbeddedData() error {
 		return fmt.Errorf("加载嵌入拼音分词数据失败: %v", err)
 	}
 
+	// 加载声调数据
+	if err := c.loadEmbeddedSoundData(); err != nil {
+		return fmt.Errorf("加载嵌入声调数据失败: %v", err)
+	}
+
+	// 加载词组数据（依赖声调数据）
+	if err := c.loadEmbeddedPhraseData(); err != nil {
+		return fmt.Errorf("加载嵌入词组数据失败: %v", err)
+	}
+
+	// 加载输入法词库（依赖声调数据）
+	if err := c.loadEmbeddedWordData(); err != nil {
+		return fmt.Errorf("加载嵌入输入法词库失败: %v", err)
+	}
+
+	// 加载姓氏数据（依赖声调数据）
+	if err := c.loadEmbeddedSurnameData(); err != nil {
+		return fmt.Errorf("加载嵌入姓氏数据失败: %v", err)
+	}
+
+	// 加载字频数据
+	if err := c.loadEmbeddedFrequencyData(); err != nil {
+		return fmt.Errorf("加载嵌入字频数据失败: %v", err)
+	}
+
 	return nil
 }
 
@@ -38,6 +75,7 @@ func (c *Chinese) loadEmbeddedCharsData() error {
 	}
 
 	// 使用现有的解析函数
+	c.resetIndexes()
 	return c.parseCharsDataFormat4(data)
 }
 
@@ -48,23 +86,70 @@ func (c *Chinese) loadEmbeddedPinyinSplitData() error {
 		return err
 	}
 
-	// 解析拼音分词数据
+	// 解析拼音分词数据（音节前缀树）
 	if splitData, ok := data["split"].(map[string]interface{}); ok {
 		if relation, ok := splitData["relation"].(map[string]interface{}); ok {
-			for key, value := range relation {
-				if valueStr, ok := value.(string); ok {
-					// 将字符串按空格分割为切片
-					if valueStr != "" {
-						c.pinyinSplitData[key] = []string{valueStr}
-					}
-				}
-			}
+			return c.parsePinyinSplitData(relation)
 		}
 	}
 
 	return nil
 }
 
+// loadEmbeddedSoundData 加载嵌入的声调数据
+func (c *Chinese) loadEmbeddedSoundData() error {
+	var data map[string]interface{}
+	if err := json.Unmarshal(embeddedPinyinData, &data); err != nil {
+		return err
+	}
+
+	if soundData, ok := data["sound"].(map[string]interface{}); ok {
+		return c.parseSoundData(soundData)
+	}
+
+	return nil
+}
+
+// loadEmbeddedPhraseData 加载嵌入的词组数据
+func (c *Chinese) loadEmbeddedPhraseData() error {
+	var data map[string]string
+	if err := json.Unmarshal(embeddedPhrasesData, &data); err != nil {
+		return err
+	}
+
+	return c.parsePhraseData(data)
+}
+
+// loadEmbeddedWordData 加载嵌入的输入法词库
+func (c *Chinese) loadEmbeddedWordData() error {
+	var data map[string]string
+	if err := json.Unmarshal(embeddedWordsData, &data); err != nil {
+		return err
+	}
+
+	return c.parseWordData(data)
+}
+
+// loadEmbeddedSurnameData 加载嵌入的姓氏数据
+func (c *Chinese) loadEmbeddedSurnameData() error {
+	var data map[string]string
+	if err := json.Unmarshal(embeddedSurnamesData, &data); err != nil {
+		return err
+	}
+
+	return c.parseSurnameData(data)
+}
+
+// loadEmbeddedFrequencyData 加载嵌入的字频数据
+func (c *Chinese) loadEmbeddedFrequencyData() error {
+	var data map[string]int
+	if err := json.Unmarshal(embeddedFrequencyData, &data); err != nil {
+		return err
+	}
+
+	return c.parseFrequencyData(data)
+}
+
 // NewChineseWithFullData 创建包含完整数据的Chinese实例
 func NewChineseWithFullData() *Chinese {
 	c := NewChinese()
@@ -0,0 +1,37 @@
+package zhkit
+
+// mergeErhua 将儿化的"儿"合并到前一个音节，如 一点儿 → yī diǎnr
+// 紧跟在汉字后的"儿"默认视为儿化后缀（如 这点儿、老头儿、味儿）；
+// 词组词典中读 ér 的"儿"（如 儿子、女儿、育儿、儿歌）按实词处理，不合并
+func (c *Chinese) mergeErhua(units []pinyinUnit) []pinyinUnit {
+	merged := make([]pinyinUnit, 0, len(units))
+
+	for _, unit := range units {
+		if unit.text == "儿" && unit.pinyins != nil && len(merged) > 0 {
+			prev := &merged[len(merged)-1]
+			if prev.pinyins != nil && prev.end == unit.start && c.isErhuaSuffix(unit) {
+				pinyins := make([]string, len(prev.pinyins))
+				for i, py := range prev.pinyins {
+					pinyins[i] = py + "r"
+				}
+				prev.text += unit.text
+				prev.pinyins = pinyins
+				prev.end = unit.end
+				prev.phrase = false
+				continue
+			}
+		}
+		merged = append(merged, unit)
+	}
+
+	return merged
+}
+
+// isErhuaSuffix 判断"儿"是否为儿化后缀：不在词组中，或词组词典中读轻声
+func (c *Chinese) isErhuaSuffix(unit pinyinUnit) bool {
+	if !unit.phrase {
+		return true
+	}
+	_, tone := c.splitTone(unit.pinyins[0])
+	return tone == neutralTone
+}
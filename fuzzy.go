@@ -0,0 +1,164 @@
+package zhkit
+
+import "strings"
+
+// FuzzyRule 模糊音规则，可以使用位运算组合多条规则
+type FuzzyRule int
+
+const (
+	FuzzyZZh   FuzzyRule = 1 << iota // z = zh
+	FuzzyCCh                         // c = ch
+	FuzzySSh                         // s = sh
+	FuzzyNL                          // n = l
+	FuzzyFH                          // f = h
+	FuzzyAnAng                       // an = ang（含 ian/iang、uan/uang）
+	FuzzyEnEng                       // en = eng
+	FuzzyInIng                       // in = ing
+
+	// FuzzyAll 全部模糊音规则
+	FuzzyAll = FuzzyZZh | FuzzyCCh | FuzzySSh | FuzzyNL | FuzzyFH | FuzzyAnAng | FuzzyEnEng | FuzzyInIng
+)
+
+// fuzzyPair 一条模糊音规则对应的两种写法，canonical 为比较时统一使用的写法
+type fuzzyPair struct {
+	rule      FuzzyRule
+	canonical string
+	other     string
+}
+
+// fuzzyInitialPairs 模糊声母
+var fuzzyInitialPairs = []fuzzyPair{
+	{FuzzyZZh, "z", "zh"},
+	{FuzzyCCh, "c", "ch"},
+	{FuzzySSh, "s", "sh"},
+	{FuzzyNL, "n", "l"},
+	{FuzzyFH, "f", "h"},
+}
+
+// fuzzyFinalPairs 模糊韵母（按韵尾匹配，先匹配带 ng 的写法）
+var fuzzyFinalPairs = []fuzzyPair{
+	{FuzzyAnAng, "an", "ang"},
+	{FuzzyEnEng, "en", "eng"},
+	{FuzzyInIng, "in", "ing"},
+}
+
+// splitInitial 将无调拼音拆分为声母和韵母，零声母音节的声母为空
+func splitInitial(base string) (string, string) {
+	for _, initial := range pinyinInitials {
+		if strings.HasPrefix(base, initial) && len(base) > len(initial) {
+			return initial, base[len(initial):]
+		}
+	}
+	return "", base
+}
+
+// fuzzyInitials 返回声母在规则下的所有写法（含自身）
+func fuzzyInitials(initial string, rules FuzzyRule) []string {
+	for _, pair := range fuzzyInitialPairs {
+		if rules&pair.rule == 0 {
+			continue
+		}
+		switch initial {
+		case pair.canonical:
+			return []string{initial, pair.other}
+		case pair.other:
+			return []string{initial, pair.canonical}
+		}
+	}
+	return []string{initial}
+}
+
+// fuzzyFinals 返回韵母在规则下的所有写法（含自身）
+func fuzzyFinals(final string, rules FuzzyRule) []string {
+	for _, pair := range fuzzyFinalPairs {
+		if rules&pair.rule == 0 {
+			continue
+		}
+		if strings.HasSuffix(final, pair.other) {
+			return []string{final, strings.TrimSuffix(final, pair.other) + pair.canonical}
+		}
+		if strings.HasSuffix(final, pair.canonical) {
+			return []string{final, strings.TrimSuffix(final, pair.canonical) + pair.other}
+		}
+	}
+	return []string{final}
+}
+
+// fuzzyVariants 返回无调拼音在规则下的模糊音写法（不含自身）
+func fuzzyVariants(base string, rules FuzzyRule) []string {
+	if rules == 0 {
+		return nil
+	}
+
+	initial, final := splitInitial(base)
+	variants := make([]string, 0)
+	for _, i := range fuzzyInitials(initial, rules) {
+		for _, f := range fuzzyFinals(final, rules) {
+			if variant := i + f; variant != base {
+				variants = append(variants, variant)
+			}
+		}
+	}
+	return variants
+}
+
+// fuzzyKey 返回无调拼音在规则下的统一写法，统一写法相同的拼音视为相同
+func fuzzyKey(base string, rules FuzzyRule) string {
+	if rules == 0 {
+		return base
+	}
+
+	initial, final := splitInitial(base)
+	for _, pair := range fuzzyInitialPairs {
+		if rules&pair.rule != 0 && initial == pair.other {
+			initial = pair.canonical
+			break
+		}
+	}
+	for _, pair := range fuzzyFinalPairs {
+		if rules&pair.rule != 0 && strings.HasSuffix(final, pair.other) {
+			final = strings.TrimSuffix(final, pair.other) + pair.canonical
+			break
+		}
+	}
+	return initial + final
+}
+
+// isFuzzyPinyin 判断是否为音节表中的音节或其在规则下的模糊音写法
+func (c *Chinese) isFuzzyPinyin(pinyin string, rules FuzzyRule) bool {
+	if c.isValidPinyin(pinyin) {
+		return true
+	}
+	for _, variant := range fuzzyVariants(pinyin, rules) {
+		if c.isValidPinyin(variant) {
+			return true
+		}
+	}
+	return false
+}
+
+// PinyinEqual 按模糊音规则比较两个拼音是否相同，fuzzy 为 0 时不启用模糊音
+// 多个音节以空白分隔；支持声调符号和数字声调，两边都标出声调时声调也必须相同，ü 可写作 v，lüe、nüe 也可写作 lue、nue
+func (c *Chinese) PinyinEqual(a, b string, fuzzy FuzzyRule) bool {
+	syllablesA, syllablesB := strings.Fields(a), strings.Fields(b)
+	if len(syllablesA) != len(syllablesB) {
+		return false
+	}
+
+	for i := range syllablesA {
+		baseA, toneA := c.parsePinyinQuery(syllablesA[i])
+		baseB, toneB := c.parsePinyinQuery(syllablesB[i])
+		if fuzzyKey(baseA, fuzzy) != fuzzyKey(baseB, fuzzy) {
+			return false
+		}
+		if toneA != 0 && toneB != 0 && toneA != toneB {
+			return false
+		}
+	}
+	return true
+}
+
+// PinyinEqual 全局函数：按模糊音规则比较两个拼音
+func PinyinEqual(a, b string, fuzzy FuzzyRule) bool {
+	return defaultChinese.PinyinEqual(a, b, fuzzy)
+}
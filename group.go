@@ -0,0 +1,154 @@
+package zhkit
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// DefaultOtherLetter 非汉字、非字母开头的分组名
+const DefaultOtherLetter = "#"
+
+// GroupOptions 按拼音首字母分组选项
+type GroupOptions struct {
+	IsName      bool   // 是否为姓名，为 true 时姓氏使用姓氏读音（如 曾 zēng、单 shàn）
+	OtherLetter string // 非汉字、非字母开头的分组名，默认为 DefaultOtherLetter
+}
+
+// Section 按首字母分组的一组数据
+type Section[T any] struct {
+	Letter string `json:"letter"` // 分组名，大写字母 A-Z 或 OtherLetter
+	Items  []T    `json:"items"`  // 组内数据，按拼音排序
+}
+
+// GroupByLetter 按拼音首字母将字符串分组（通讯录、城市列表等的 A-Z 索引）
+// 汉字开头的按拼音首字母分组，拉丁字母开头的按去掉附加符号后的字母分组（Émile 归入 E），其余放入 OtherLetter 分组
+// 分组按 A-Z 排列，OtherLetter 分组排在最后，只返回有数据的分组
+func (c *Chinese) GroupByLetter(texts []string, options *GroupOptions) []Section[string] {
+	return GroupByLetterFunc(c, texts, func(text string) string { return text }, options)
+}
+
+// GroupByLetterFunc 按 key 返回的字符串的拼音首字母将数据分组，c 为 nil 时使用全局实例
+// 分组规则与 GroupByLetter 相同，组内按 key 的拼音排序，排序相同时保持原顺序
+func GroupByLetterFunc[T any](c *Chinese, items []T, key func(T) string, options *GroupOptions) []Section[T] {
+	if c == nil {
+		c = defaultChinese
+	}
+	if options == nil {
+		options = &GroupOptions{}
+	}
+	other := options.OtherLetter
+	if other == "" {
+		other = DefaultOtherLetter
+	}
+
+	collator := &Collator{chinese: c, isName: options.IsName}
+	type keyedItem struct {
+		item    T
+		sortKey []byte
+	}
+	groups := make(map[string][]keyedItem)
+	for _, item := range items {
+		text := strings.TrimSpace(key(item))
+		letter := c.groupLetter(text, options.IsName)
+		if letter == "" {
+			letter = other
+		}
+		groups[letter] = append(groups[letter], keyedItem{item: item, sortKey: collator.SortKey(text)})
+	}
+
+	letters := make([]string, 0, len(groups))
+	for letter := range groups {
+		if letter != other {
+			letters = append(letters, letter)
+		}
+	}
+	slices.Sort(letters)
+	if _, exists := groups[other]; exists {
+		letters = append(letters, other)
+	}
+
+	sections := make([]Section[T], 0, len(letters))
+	for _, letter := range letters {
+		group := groups[letter]
+		slices.SortStableFunc(group, func(a, b keyedItem) int {
+			return bytes.Compare(a.sortKey, b.sortKey)
+		})
+		section := Section[T]{Letter: letter, Items: make([]T, len(group))}
+		for i, keyed := range group {
+			section.Items[i] = keyed.item
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// latinLetterVariants 带附加符号的拉丁字母（大写）对应的基本字母，用于分组时折叠附加符号
+var latinLetterVariants = map[rune]string{
+	'A': "ÀÁÂÃÄÅĀĂĄǍ",
+	'C': "ÇĆĈĊČ",
+	'D': "ĎĐ",
+	'E': "ÈÉÊËĒĔĖĘĚ",
+	'G': "ĜĞĠĢ",
+	'H': "ĤĦ",
+	'I': "ÌÍÎÏĨĪĬĮİǏ",
+	'J': "Ĵ",
+	'K': "Ķ",
+	'L': "ĹĻĽĿŁ",
+	'N': "ÑŃŅŇ",
+	'O': "ÒÓÔÕÖØŌŎŐǑ",
+	'R': "ŔŖŘ",
+	'S': "ŚŜŞŠ",
+	'T': "ŢŤŦ",
+	'U': "ÙÚÛÜŨŪŬŮŰŲǓǕǗǙǛ",
+	'W': "Ŵ",
+	'Y': "ÝŶŸ",
+	'Z': "ŹŻŽ",
+}
+
+// latinBaseLetter 返回拉丁字母去掉附加符号后的大写基本字母（é → E），不是 A-Z 或其变体时返回 0
+func latinBaseLetter(r rune) rune {
+	r = unicode.ToUpper(r)
+	if r >= 'A' && r <= 'Z' {
+		return r
+	}
+	for base, variants := range latinLetterVariants {
+		if strings.ContainsRune(variants, r) {
+			return base
+		}
+	}
+	return 0
+}
+
+// groupLetter 返回文本第一个字符所属的分组字母（大写）
+// 汉字取拼音首字母；拉丁字母取去掉附加符号后的基本字母（é、Ü 分别归入 E、U），
+// 其他字符（数字、符号、没有对应基本字母的拉丁字母如 Æ、ß 等）返回空字符串
+func (c *Chinese) groupLetter(text string, isName bool) string {
+	runes := []rune(text)
+	if len(runes) == 0 {
+		return ""
+	}
+
+	if unicode.Is(unicode.Latin, runes[0]) {
+		if r := latinBaseLetter(runes[0]); r != 0 {
+			return string(r)
+		}
+		return ""
+	}
+
+	units := c.pinyinUnits(text, &PinyinOptions{SplitNonChinese: true, Polyphone: PolyphoneFirst, IsName: isName})
+	if len(units) == 0 || units[0].pinyins == nil {
+		return ""
+	}
+	letter := strings.ToUpper(c.firstLetter(units[0].pinyins[0]))
+	if letter < "A" || letter > "Z" {
+		return ""
+	}
+	return letter
+}
+
+// GroupByLetter 全局函数：按拼音首字母将字符串分组
+func GroupByLetter(texts []string, options *GroupOptions) []Section[string] {
+	return defaultChinese.GroupByLetter(texts, options)
+}
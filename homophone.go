@@ -0,0 +1,172 @@
+package zhkit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultHomophoneLimit 同音词的默认数量上限
+const DefaultHomophoneLimit = 100
+
+// homophoneCharsPerLevel 每个读音在每个同音程度下最多取的候选字数（按字频）
+const homophoneCharsPerLevel = 10
+
+// HomophoneMatch 同音程度
+type HomophoneMatch int
+
+const (
+	// HomophoneExact 读音和声调都相同
+	HomophoneExact HomophoneMatch = iota
+	// HomophoneToneDiff 读音相同、声调不同
+	HomophoneToneDiff
+	// HomophoneFuzzy 模糊音相近（如 zh/z、n/l、an/ang）
+	HomophoneFuzzy
+)
+
+// HomophoneOptions 同音词查找选项
+type HomophoneOptions struct {
+	IgnoreTone bool      // 是否包含声调不同的同音字
+	Fuzzy      FuzzyRule // 包含按哪些模糊音规则相近的字，如 FuzzyAll，为 0 时不包含
+	Limit      int       // 最多返回的结果数，<= 0 时为 DefaultHomophoneLimit
+}
+
+// Homophone 同音词
+type Homophone struct {
+	Text   string         `json:"text"`   // 同音词
+	Pinyin []string       `json:"pinyin"` // 每个字的读音（带声调）
+	Match  HomophoneMatch `json:"match"`  // 同音程度，取各字中最低的一级
+}
+
+// homophoneChar 某个位置的候选字
+type homophoneChar struct {
+	char   rune
+	pinyin string
+	match  HomophoneMatch
+}
+
+// Homophones 查找与 word 读音相同或相近的其他字串
+// 每个字按词组读音或第一个读音反查同音的常用字，候选字按同音程度、字频排序，
+// 结果按同音程度、各字候选排位之和从小到大返回（改动字少的在前），不包含 word 本身
+func (c *Chinese) Homophones(word string, options *HomophoneOptions) ([]Homophone, error) {
+	if options == nil {
+		options = &HomophoneOptions{}
+	}
+	limit := options.Limit
+	if limit <= 0 {
+		limit = DefaultHomophoneLimit
+	}
+
+	// 按 word 实际的读音（词组读音或第一个读音）判断同音程度，不使用多音字的其他读音
+	units := c.pinyinUnits(word, &PinyinOptions{Polyphone: PolyphoneFirst})
+	if len(units) == 0 {
+		return nil, fmt.Errorf("输入为空")
+	}
+
+	positions := make([][]homophoneChar, len(units))
+	candidates := make([][]string, len(units))
+	levels := make([][]int, len(units))
+	for i, unit := range units {
+		if unit.pinyins == nil {
+			positions[i] = []homophoneChar{{pinyin: unit.text}}
+			candidates[i] = []string{unit.text}
+			levels[i] = []int{int(HomophoneExact)}
+			continue
+		}
+
+		positions[i] = c.homophoneChars([]rune(unit.text)[0], unit.pinyins, options)
+		candidates[i] = make([]string, len(positions[i]))
+		levels[i] = make([]int, len(positions[i]))
+		for j, char := range positions[i] {
+			candidates[i][j] = string(char.char)
+			levels[i][j] = int(char.match)
+		}
+	}
+
+	homophones := make([]Homophone, 0)
+	// 按同音程度枚举，保证截断时保留同音程度高的结果；多取一个，用于跳过 word 本身
+	for combination := range rankedCombinations(candidates, levels, limit+1) {
+		text := strings.Join(combination, "")
+		if text == word {
+			continue
+		}
+
+		homophone := Homophone{Text: text, Pinyin: make([]string, 0, len(combination))}
+		for i, value := range combination {
+			for _, char := range positions[i] {
+				if char.char == 0 || string(char.char) == value {
+					homophone.Pinyin = append(homophone.Pinyin, char.pinyin)
+					homophone.Match = max(homophone.Match, char.match)
+					break
+				}
+			}
+		}
+
+		homophones = append(homophones, homophone)
+		if len(homophones) == limit {
+			break
+		}
+	}
+
+	return homophones, nil
+}
+
+// homophoneChars 返回与任一读音同音的常用字，原字排在最前，其余按同音程度、字频排序并去重
+func (c *Chinese) homophoneChars(original rune, pinyins []string, options *HomophoneOptions) []homophoneChar {
+	chars := []homophoneChar{{char: original, pinyin: pinyins[0]}}
+	seen := map[rune]bool{original: true}
+	add := func(base string, tone int, match HomophoneMatch) {
+		count := 0
+		for _, char := range c.lookupHanzi(base, tone) {
+			if count == homophoneCharsPerLevel {
+				break
+			}
+			// 跳过已有的字和没有字频、常用字标记的生僻字
+			if seen[char] || (c.charRank[char] == 0 && c.charWeight[char] == 0) {
+				continue
+			}
+			seen[char] = true
+			count++
+			chars = append(chars, homophoneChar{char: char, pinyin: c.readingOf(char, base, tone), match: match})
+		}
+	}
+
+	for _, py := range pinyins {
+		base, tone := c.splitTone(py)
+		add(strings.ToLower(base), tone, HomophoneExact)
+	}
+	if options.IgnoreTone {
+		for _, py := range pinyins {
+			base, _ := c.splitTone(py)
+			add(strings.ToLower(base), 0, HomophoneToneDiff)
+		}
+	}
+	if options.Fuzzy != 0 {
+		for _, py := range pinyins {
+			base, tone := c.splitTone(py)
+			if options.IgnoreTone {
+				tone = 0
+			}
+			for _, variant := range fuzzyVariants(strings.ToLower(base), options.Fuzzy) {
+				add(variant, tone, HomophoneFuzzy)
+			}
+		}
+	}
+
+	return chars
+}
+
+// readingOf 返回字的与无调拼音和声调匹配的读音，tone 为 0 时不限声调
+func (c *Chinese) readingOf(char rune, base string, tone int) string {
+	for _, py := range c.pinyinData[char] {
+		pyBase, pyTone := c.splitTone(py)
+		if strings.ToLower(pyBase) == base && (tone == 0 || pyTone == tone) {
+			return py
+		}
+	}
+	return ""
+}
+
+// Homophones 全局函数：查找同音词
+func Homophones(word string, options *HomophoneOptions) ([]Homophone, error) {
+	return defaultChinese.Homophones(word, options)
+}
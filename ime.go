@@ -0,0 +1,453 @@
+package zhkit
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Candidate 输入法候选
+type Candidate struct {
+	Text     string   `json:"text"`     // 候选汉字
+	Pinyin   []string `json:"pinyin"`   // 每个字的读音（带声调）
+	Consumed int      `json:"consumed"` // 消耗的输入长度（字节），剩余部分可继续调用 Candidates
+}
+
+// candidateAbbrevChars 简拼（只输入声母）时返回的单字候选数
+const candidateAbbrevChars = 20
+
+// candidateSplitLimit 组词时参考的拼音分词方案数
+const candidateSplitLimit = 20
+
+// CandidateOptions 拼音输入法候选选项
+type CandidateOptions struct {
+	Fuzzy FuzzyRule // 同时按哪些模糊音规则匹配（如 FuzzyZZh 时 zong 也能输入 中），为 0 时不启用
+}
+
+// candidateMatch 候选及排序信息
+type candidateMatch struct {
+	Candidate
+	abbrevs int // 以简拼匹配的字数
+	fuzzy   int // 以模糊音匹配的字数
+	rank    int // 各字的字频排名之和，越小越常用
+}
+
+// Candidates 拼音输入法：根据拼音输入返回汉字候选
+// input 为不带声调的拼音，如 "woaibeijing"，不区分大小写，支持简拼（"bjdx"）、混合简拼（"beijdx"）和隔音符号（"xi'an"），
+// 空白与隔音符号相同，作为音节边界；Consumed 为原始输入中的字节位置（包括跳过的空白）
+// 输入按拼音分词切分，使用输入法词库（data/wordsData.json）和词组词典组词，第一个候选为整句组词结果（如有），
+// 其余按消耗的输入长度、简拼字数、字数、字频排序
+func (c *Chinese) Candidates(input string) ([]Candidate, error) {
+	return c.CandidatesWithOptions(input, nil)
+}
+
+// CandidatesWithOptions 拼音输入法候选（使用选项）
+// 启用模糊音时，如果输入能按准确读音完整切分为音节（如 yangsheng），模糊音匹配的候选排在所有准确匹配之后，
+// 即使准确匹配消耗的输入更短；否则（如 tin 只能按 in/ing 模糊音理解）模糊音匹配排在消耗输入长度、简拼字数相同的准确匹配之后
+func (c *Chinese) CandidatesWithOptions(input string, options *CandidateOptions) ([]Candidate, error) {
+	if options == nil {
+		options = &CandidateOptions{}
+	}
+
+	// 字母转小写、空白替换为等长的隔音符号，保持与原始输入相同的字节位置
+	var normalized strings.Builder
+	for _, r := range input {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			normalized.WriteRune(unicode.ToLower(r))
+		case r >= 'a' && r <= 'z' || r == '\'':
+			normalized.WriteRune(r)
+		case unicode.IsSpace(r):
+			normalized.WriteString(strings.Repeat("'", utf8.RuneLen(r)))
+		default:
+			return nil, fmt.Errorf("无法识别的拼音输入: %s", input)
+		}
+	}
+	input = normalized.String()
+	if strings.Trim(input, "'") == "" {
+		return nil, fmt.Errorf("输入为空")
+	}
+
+	reachable, ok := c.candidateBoundaries(input, options.Fuzzy)
+	if !ok {
+		return nil, fmt.Errorf("无法切分的拼音输入: %s", input)
+	}
+
+	_, fuzzyLast := c.splitPinyinPaths(input, &SplitOptions{Limit: 1})
+	matches := c.candidatesAt(input, 0, reachable, options.Fuzzy, fuzzyLast)
+	candidates := make([]Candidate, 0, len(matches)+1)
+	sentence, ok := c.sentenceCandidate(input, reachable, options.Fuzzy, fuzzyLast)
+	if ok {
+		candidates = append(candidates, sentence)
+	}
+	for _, match := range matches {
+		if !ok || match.Text != sentence.Text {
+			candidates = append(candidates, match.Candidate)
+		}
+	}
+	return candidates, nil
+}
+
+// sentenceCandidate 从头到尾每次取最优候选组成整句，只有多于一个词时返回
+func (c *Chinese) sentenceCandidate(input string, reachable []bool, fuzzy FuzzyRule, fuzzyLast bool) (Candidate, bool) {
+	sentence := Candidate{}
+	pieces := 0
+	for pos := skipApostrophes(input, 0); pos < len(input); pos = skipApostrophes(input, pos) {
+		matches := c.candidatesAt(input, pos, reachable, fuzzy, fuzzyLast)
+		if len(matches) == 0 {
+			return Candidate{}, false
+		}
+		sentence.Text += matches[0].Text
+		sentence.Pinyin = append(sentence.Pinyin, matches[0].Pinyin...)
+		pos = matches[0].Consumed
+		pieces++
+	}
+	sentence.Consumed = len(input)
+	return sentence, pieces > 1
+}
+
+// skipApostrophes 跳过隔音符号
+func skipApostrophes(input string, pos int) int {
+	for pos < len(input) && input[pos] == '\'' {
+		pos++
+	}
+	return pos
+}
+
+// candidateBoundaries 按拼音分词（允许简拼）的最优 candidateSplitLimit 种方案，
+// 标记输入中可以作为词边界的位置（隔音符号前后都标记），无法切分时返回 false
+func (c *Chinese) candidateBoundaries(input string, fuzzy FuzzyRule) ([]bool, bool) {
+	paths, ok := c.splitPinyinPaths(input, &SplitOptions{Limit: candidateSplitLimit, Fuzzy: fuzzy, Abbreviation: true})
+	if !ok {
+		return nil, false
+	}
+
+	// 按字母数（不含隔音符号）记录各方案的音节边界，输入只含 ASCII 字母和隔音符号
+	boundaries := make(map[int]bool)
+	for _, path := range paths {
+		letters := 0
+		boundaries[letters] = true
+		for _, syllable := range path.syllables {
+			letters += len(syllable)
+			boundaries[letters] = true
+		}
+	}
+
+	reachable := make([]bool, len(input)+1)
+	letters := 0
+	for pos := 0; pos <= len(input); pos++ {
+		reachable[pos] = boundaries[letters]
+		if pos < len(input) && input[pos] != '\'' {
+			letters++
+		}
+	}
+	return reachable, true
+}
+
+// isSyllable 判断 input[start:end] 是否为音节表中的音节，lüe、nüe 也可写作 lve、nve
+// 自成音节的鼻音（ng、hm、hng）与 SplitPinyin 相同，只在单独成段（前后是输入边界或隔音符号）时接受；
+// 单独的 m、n 按简拼处理，不作为自成音节的鼻音
+func (c *Chinese) isSyllable(input string, start, end int) bool {
+	pinyin := input[start:end]
+	if pinyin == "m" || pinyin == "n" {
+		return false
+	}
+	standalone := (start == 0 || input[start-1] == '\'') && (end == len(input) || input[end] == '\'')
+	if isEmbeddedNasal(pinyin, standalone) {
+		return false
+	}
+	return c.isValidPinyin(pinyin) || c.isValidPinyin(strings.Replace(pinyin, "ve", "ue", 1))
+}
+
+// umlautSyllable 将 lue、nue 还原为 lve、nve（ü 记为 v）
+func umlautSyllable(syllable string) string {
+	if syllable == "lue" || syllable == "nue" {
+		return syllable[:1] + "ve"
+	}
+	return syllable
+}
+
+// isAbbreviation 判断是否为简拼：单个辅音字母或 zh、ch、sh
+func isAbbreviation(text string) bool {
+	switch text {
+	case "zh", "ch", "sh":
+		return true
+	}
+	return len(text) == 1 && !strings.Contains("aeiou'", text)
+}
+
+// candidatesAt 返回从 pos 开始的候选（词组和单字），结束位置之后的输入必须能完整切分
+// fuzzyLast 为 true 时模糊音匹配的候选排在所有准确匹配之后，否则先按消耗的输入长度和简拼字数排序
+func (c *Chinese) candidatesAt(input string, pos int, reachable []bool, fuzzy FuzzyRule, fuzzyLast bool) []candidateMatch {
+	pos = skipApostrophes(input, pos)
+	matches := make([]candidateMatch, 0)
+	if pos == len(input) {
+		return matches
+	}
+
+	// 词：输入法词库和词组词典
+	for _, word := range c.wordsAt(input, pos) {
+		best := candidateMatch{abbrevs: -1}
+		matchReadings(input, pos, word.bases, fuzzy, 0, 0, func(end, abbrevs, fuzzies int) {
+			if !reachable[end] {
+				return
+			}
+			if best.abbrevs < 0 || end > best.Consumed ||
+				(end == best.Consumed && (abbrevs < best.abbrevs || (abbrevs == best.abbrevs && fuzzies < best.fuzzy))) {
+				best.Consumed, best.abbrevs, best.fuzzy = end, abbrevs, fuzzies
+			}
+		})
+		if best.abbrevs >= 0 {
+			best.Text, best.Pinyin, best.rank = word.text, word.readings, c.phraseRank(word.text)
+			matches = append(matches, best)
+		}
+	}
+
+	// 单字：完整音节
+	for end := pos + 1; end <= len(input) && end-pos <= 6; end++ {
+		syllable := input[pos:end]
+		if !reachable[end] {
+			continue
+		}
+		if c.isSyllable(input, pos, end) {
+			for _, char := range c.lookupHanzi(umlautSyllable(syllable), 0) {
+				matches = append(matches, c.charCandidate(char, syllable, end, 0))
+			}
+		}
+		for _, variant := range fuzzyVariants(syllable, fuzzy) {
+			for _, char := range c.lookupHanzi(umlautSyllable(variant), 0) {
+				match := c.charCandidate(char, variant, end, 0)
+				match.fuzzy = 1
+				matches = append(matches, match)
+			}
+		}
+	}
+
+	// 单字：简拼
+	for _, abbreviation := range []string{input[pos:min(pos+2, len(input))], input[pos : pos+1]} {
+		if !isAbbreviation(abbreviation) || !reachable[pos+len(abbreviation)] {
+			continue
+		}
+		for _, char := range c.lexicon().abbrevChars[abbreviation] {
+			matches = append(matches, c.charCandidate(char, abbreviation, pos+len(abbreviation), 1))
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b candidateMatch) int {
+		switch {
+		case fuzzyLast && (a.fuzzy > 0) != (b.fuzzy > 0):
+			if a.fuzzy > 0 {
+				return 1
+			}
+			return -1
+		case a.Consumed != b.Consumed:
+			return b.Consumed - a.Consumed
+		case a.abbrevs != b.abbrevs:
+			return a.abbrevs - b.abbrevs
+		case a.fuzzy != b.fuzzy:
+			return a.fuzzy - b.fuzzy
+		case len(a.Pinyin) != len(b.Pinyin):
+			return len(a.Pinyin) - len(b.Pinyin)
+		case len(a.Pinyin) > 1 && a.rank != b.rank:
+			return a.rank - b.rank
+		case len(a.Pinyin) > 1:
+			return strings.Compare(a.Text, b.Text)
+		}
+		return 0
+	})
+
+	// 同一个字可能有多个读音匹配，只保留排序最前的
+	seen := make(map[string]bool, len(matches))
+	return slices.DeleteFunc(matches, func(match candidateMatch) bool {
+		if seen[match.Text] {
+			return true
+		}
+		seen[match.Text] = true
+		return false
+	})
+}
+
+// lexiconWord 组词索引中的一个词
+type lexiconWord struct {
+	text     string
+	readings []string // 每个字的读音（带声调）
+	bases    []string // 每个字的无调拼音（小写，ü 记为 v）
+}
+
+// lexicon 组词索引，随拼音反查索引一起按需构建
+type lexicon struct {
+	words       []lexiconWord     // 输入法词库和词组词典中的词
+	keys        map[string][]int  // 首字读音在全部模糊音规则下的统一写法（fuzzyKey）及首字简拼声母到 words 下标
+	abbrevChars map[string][]rune // 简拼到读音以它开头的最常用的 candidateAbbrevChars 个字
+}
+
+// lexiconKeys 返回首字无调拼音 base 在组词索引中的键，与 matchReadings 对首个读音的匹配方式对应
+func lexiconKeys(base string) []string {
+	keys := []string{fuzzyKey(base, FuzzyAll)}
+	for _, initial := range []string{base[:min(2, len(base))], base[:1]} {
+		if isAbbreviation(initial) && len(initial) < len(base) && !slices.Contains(keys, initial) {
+			keys = append(keys, initial)
+		}
+	}
+	return keys
+}
+
+// buildLexicon 根据拼音反查索引 hanzi 构建组词索引
+func (c *Chinese) buildLexicon(hanzi map[string][]hanziEntry) *lexicon {
+	index := &lexicon{
+		words:       make([]lexiconWord, 0, len(c.wordData)+len(c.phraseData)),
+		keys:        make(map[string][]int),
+		abbrevChars: make(map[string][]rune),
+	}
+	for _, words := range []map[string][]string{c.wordData, c.phraseData} {
+		for word, readings := range words {
+			bases := make([]string, len(readings))
+			for i, py := range readings {
+				base, _ := c.splitTone(py)
+				bases[i] = strings.ToLower(base)
+			}
+			if len(bases) == 0 || bases[0] == "" {
+				continue
+			}
+
+			for _, key := range lexiconKeys(bases[0]) {
+				index.keys[key] = append(index.keys[key], len(index.words))
+			}
+			index.words = append(index.words, lexiconWord{text: word, readings: readings, bases: bases})
+		}
+	}
+
+	for base := range hanzi {
+		for _, initial := range []string{base[:min(2, len(base))], base[:1]} {
+			if _, exists := index.abbrevChars[initial]; !exists && isAbbreviation(initial) {
+				index.abbrevChars[initial] = c.hanziByPrefix(hanzi, initial, candidateAbbrevChars)
+			}
+		}
+	}
+	return index
+}
+
+// lexicon 返回组词索引
+func (c *Chinese) lexicon() *lexicon {
+	c.indexOnce.Do(c.buildIndexes)
+	return c.lexiconData
+}
+
+// wordsAt 返回首字读音可能与 input[pos:] 开头匹配的词（含简拼和任意模糊音规则下的匹配），是否匹配由 matchReadings 判断
+func (c *Chinese) wordsAt(input string, pos int) []lexiconWord {
+	index := c.lexicon()
+	words := make([]lexiconWord, 0)
+	seen := make(map[int]bool)
+	for end := pos + 1; end <= len(input) && end-pos <= 6 && input[end-1] != '\''; end++ {
+		prefix := input[pos:end]
+		for _, key := range []string{prefix, fuzzyKey(prefix, FuzzyAll), fuzzyKey(umlautSyllable(prefix), FuzzyAll)} {
+			for _, i := range index.keys[key] {
+				if !seen[i] {
+					seen[i] = true
+					words = append(words, index.words[i])
+				}
+			}
+		}
+	}
+	return words
+}
+
+// matchReadings 从 pos 开始按读音序列匹配输入，每个读音可以完整输入、按 fuzzy 规则输入模糊音或只输入声母（首字母）
+// 每得到一种完整匹配就以结束位置、简拼字数和模糊音字数调用 visit
+func matchReadings(input string, pos int, bases []string, fuzzy FuzzyRule, abbrevs, fuzzies int, visit func(end, abbrevs, fuzzies int)) {
+	pos = skipApostrophes(input, pos)
+	if len(bases) == 0 {
+		visit(pos, abbrevs, fuzzies)
+		return
+	}
+
+	base := strings.ToLower(bases[0])
+	rest := input[pos:]
+	if strings.HasPrefix(rest, base) {
+		matchReadings(input, pos+len(base), bases[1:], fuzzy, abbrevs, fuzzies, visit)
+	}
+	if alternative := strings.Replace(base, "ve", "ue", 1); alternative != base && strings.HasPrefix(rest, alternative) {
+		matchReadings(input, pos+len(alternative), bases[1:], fuzzy, abbrevs, fuzzies, visit)
+	}
+	for _, variant := range fuzzyVariants(base, fuzzy) {
+		if strings.HasPrefix(rest, variant) {
+			matchReadings(input, pos+len(variant), bases[1:], fuzzy, abbrevs, fuzzies+1, visit)
+		}
+	}
+	// 简拼：zh、ch、sh 也可以只输入首字母
+	for _, initial := range []string{base[:min(2, len(base))], base[:1]} {
+		if isAbbreviation(initial) && len(initial) < len(base) && strings.HasPrefix(rest, initial) {
+			matchReadings(input, pos+len(initial), bases[1:], fuzzy, abbrevs+1, fuzzies, visit)
+		}
+	}
+}
+
+// hanziByPrefix 在拼音反查索引 hanzi 中查找读音以 prefix 开头的汉字，按字频返回前 limit 个
+func (c *Chinese) hanziByPrefix(hanzi map[string][]hanziEntry, prefix string, limit int) []rune {
+	chars := make([]rune, 0)
+	seen := make(map[rune]bool)
+	for base, entries := range hanzi {
+		if !strings.HasPrefix(base, prefix) {
+			continue
+		}
+		for _, entry := range entries {
+			if !seen[entry.char] {
+				seen[entry.char] = true
+				chars = append(chars, entry.char)
+			}
+		}
+	}
+
+	slices.SortFunc(chars, func(a, b rune) int {
+		if cmp := c.compareHanzi(a, b); cmp != 0 {
+			return cmp
+		}
+		return int(a - b)
+	})
+	return chars[:min(limit, len(chars))]
+}
+
+// charCandidate 生成单字候选，读音取第一个与输入匹配的读音
+func (c *Chinese) charCandidate(char rune, typed string, end, abbrevs int) candidateMatch {
+	typed = umlautSyllable(typed)
+	pinyin := ""
+	for _, py := range c.pinyinData[char] {
+		base, _ := c.splitTone(py)
+		base = strings.ToLower(base)
+		if base == typed || (abbrevs > 0 && strings.HasPrefix(base, typed)) {
+			pinyin = py
+			break
+		}
+	}
+
+	return candidateMatch{
+		Candidate: Candidate{Text: string(char), Pinyin: []string{pinyin}, Consumed: end},
+		abbrevs:   abbrevs,
+		rank:      c.phraseRank(string(char)),
+	}
+}
+
+// phraseRank 返回各字的字频排名之和，没有字频数据的字按字频表长度计
+func (c *Chinese) phraseRank(phrase string) int {
+	rank := 0
+	for _, char := range phrase {
+		if charRank, exists := c.charRank[char]; exists {
+			rank += charRank
+		} else {
+			rank += len(c.charRank) + 1
+		}
+	}
+	return rank
+}
+
+// Candidates 全局函数：拼音输入法候选
+func Candidates(input string) ([]Candidate, error) {
+	return defaultChinese.Candidates(input)
+}
+
+// CandidatesWithOptions 全局函数：拼音输入法候选（使用选项）
+func CandidatesWithOptions(input string, options *CandidateOptions) ([]Candidate, error) {
+	return defaultChinese.CandidatesWithOptions(input, options)
+}
@@ -0,0 +1,133 @@
+package zhkit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ipaInitials 声母与国际音标对照
+var ipaInitials = map[string]string{
+	"b": "p", "p": "pʰ", "m": "m", "f": "f",
+	"d": "t", "t": "tʰ", "n": "n", "l": "l",
+	"g": "k", "k": "kʰ", "h": "x",
+	"j": "tɕ", "q": "tɕʰ", "x": "ɕ",
+	"zh": "ʈʂ", "ch": "ʈʂʰ", "sh": "ʂ", "r": "ʐ",
+	"z": "ts", "c": "tsʰ", "s": "s",
+}
+
+// ipaFinals 韵母（标准写法）与国际音标对照
+// 介音 i、u、ü 记为 j、w、ɥ，ian、üan 的主要元音记为 ɛ
+var ipaFinals = map[string]string{
+	"a": "a", "o": "wo", "e": "ɤ", "ê": "ɛ",
+	"ai": "aɪ", "ei": "eɪ", "ao": "ɑʊ", "ou": "oʊ",
+	"an": "an", "en": "ən", "ang": "ɑŋ", "eng": "ɤŋ", "ong": "ʊŋ", "er": "ɚ",
+	"i": "i", "ia": "ja", "io": "jɔ", "ie": "jɛ", "iai": "jaɪ", "iao": "jɑʊ", "iou": "joʊ",
+	"ian": "jɛn", "in": "in", "iang": "jɑŋ", "ing": "iŋ", "iong": "jʊŋ",
+	"u": "u", "ua": "wa", "uo": "wo", "uai": "waɪ", "uei": "weɪ",
+	"uan": "wan", "uen": "wən", "uang": "wɑŋ", "ueng": "wɤŋ",
+	"v": "y", "ve": "ɥɛ", "van": "ɥɛn", "vn": "yn",
+	"m": "m̩", "n": "n̩", "ng": "ŋ̍",
+}
+
+// ipaToneLetters 五度调符号，下标即声调
+var ipaToneLetters = []string{"", "˥", "˧˥", "˨˩˦", "˥˩"}
+
+// ipaToneNumbers 五度调值，下标即声调
+var ipaToneNumbers = []string{"", "55", "35", "214", "51"}
+
+// ipaErhua 儿化韵尾
+const ipaErhua = "ɻ"
+
+// ipaSpelling 将声母和标准写法的韵母转换为国际音标
+func ipaSpelling(initial, final string) string {
+	spelled := ipaFinals[final]
+	switch {
+	case final == "":
+		// 舌尖元音：zhi、chi、shi、ri 为 ɻ̩，zi、ci、si 为 ɹ̩
+		spelled = "ɻ̩"
+		if initial == "z" || initial == "c" || initial == "s" {
+			spelled = "ɹ̩"
+		}
+	case final == "o" && initial == "":
+		// 零声母的 o 没有介音
+		spelled = "o"
+	}
+	return ipaInitials[initial] + spelled
+}
+
+// ipaTone 按声调写法返回国际音标的声调标记，轻声不标
+func ipaTone(tone int, style ToneStyle) string {
+	if tone < 1 || tone > 4 {
+		return ""
+	}
+	switch style {
+	case ToneLetter:
+		return ipaToneLetters[tone]
+	case ToneSuperscript:
+		var builder strings.Builder
+		for _, digit := range ipaToneNumbers[tone] {
+			builder.WriteString(toneSuperscripts[digit-'0'])
+		}
+		return builder.String()
+	case ToneNone:
+		return ""
+	default:
+		return ipaToneNumbers[tone]
+	}
+}
+
+// ipaSyllable 将一个带声调的拼音音节转换为国际音标
+// 与 romanizeSyllable 相同，只接受拼音音节表中的音节、自成音节的鼻音以及它们的儿化形式
+func (c *Chinese) ipaSyllable(pinyin string, style ToneStyle) (string, bool) {
+	base, tone := c.splitTone(pinyin)
+	base = strings.ToLower(base)
+
+	erhua := false
+	if len(base) > 2 && strings.HasSuffix(base, "r") {
+		base, erhua = base[:len(base)-1], true
+	}
+
+	initial, final, ok := splitSyllable(base)
+	if !ok {
+		return "", false
+	}
+	if !isSyllabicNasal(initial, final) && !c.isValidPinyin(strings.Replace(base, "ve", "ue", 1)) {
+		return "", false
+	}
+
+	ipa := ipaSpelling(initial, final)
+	if erhua {
+		ipa += ipaErhua
+	}
+	return ipa + ipaTone(tone, style), true
+}
+
+// toIPA 将带声调的拼音转换为国际音标，无法识别时返回原文
+func (c *Chinese) toIPA(pinyin string, style ToneStyle) string {
+	if ipa, ok := c.ipaSyllable(pinyin, style); ok {
+		return ipa
+	}
+	return pinyin
+}
+
+// PinyinToIPA 拼音转国际音标
+// 输入以空白分隔的音节，支持声调符号（zhōng）、数字声调（zhong1）和无调拼音
+func (c *Chinese) PinyinToIPA(pinyin string, style ToneStyle) (string, error) {
+	syllables := strings.Fields(pinyin)
+	results := make([]string, len(syllables))
+
+	for i, syllable := range syllables {
+		ipa, ok := c.ipaSyllable(syllable, style)
+		if !ok {
+			return "", fmt.Errorf("无法识别的拼音: %s", syllable)
+		}
+		results[i] = ipa
+	}
+
+	return strings.Join(results, " "), nil
+}
+
+// PinyinToIPA 全局函数：拼音转国际音标
+func PinyinToIPA(pinyin string, style ToneStyle) (string, error) {
+	return defaultChinese.PinyinToIPA(pinyin, style)
+}
@@ -0,0 +1,210 @@
+package zhkit
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MatchRange 匹配到的原文范围（字符 rune 偏移）
+type MatchRange struct {
+	Start int `json:"start"` // 起始字符偏移
+	End   int `json:"end"`   // 结束字符偏移（不含）
+}
+
+// MatchResult 匹配结果
+type MatchResult struct {
+	Index  int          `json:"index"`  // 目标在 NewMatcher 参数中的位置
+	Text   string       `json:"text"`   // 目标文本
+	Ranges []MatchRange `json:"ranges"` // 所有不重叠的匹配范围，从左到右
+}
+
+// Matcher 拼音匹配器，预先计算目标文本每个字的全部读音，用于反复匹配
+type Matcher struct {
+	targets []matchTarget
+}
+
+// matchTarget 预编译的匹配目标
+type matchTarget struct {
+	text  string
+	chars []matchChar
+}
+
+// matchChar 目标中的一个字符
+type matchChar struct {
+	char     rune     // 小写后的字符
+	readings []string // 汉字的全部无调拼音（小写，ü 记为 v，l、n 后的 ü 也记为 u，含模糊音写法），非汉字为 nil
+}
+
+// MatcherOptions 拼音匹配器选项
+type MatcherOptions struct {
+	Fuzzy FuzzyRule // 按哪些模糊音规则匹配，为 0 时不启用
+}
+
+// NewMatcher 创建拼音匹配器
+func (c *Chinese) NewMatcher(targets []string) *Matcher {
+	return c.NewMatcherWithOptions(targets, nil)
+}
+
+// NewMatcherWithOptions 创建拼音匹配器（使用选项）
+func (c *Chinese) NewMatcherWithOptions(targets []string, options *MatcherOptions) *Matcher {
+	if options == nil {
+		options = &MatcherOptions{}
+	}
+
+	m := &Matcher{targets: make([]matchTarget, len(targets))}
+	for i, target := range targets {
+		m.targets[i] = c.compileMatchTarget(target, options.Fuzzy)
+	}
+	return m
+}
+
+// compileMatchTarget 计算目标文本每个字的全部读音（含规则下的模糊音写法）
+func (c *Chinese) compileMatchTarget(text string, fuzzy FuzzyRule) matchTarget {
+	target := matchTarget{text: text, chars: make([]matchChar, 0, utf8.RuneCountInString(text))}
+	for _, r := range text {
+		char := matchChar{char: unicode.ToLower(r)}
+		for _, py := range c.pinyinData[r] {
+			base, _ := c.splitTone(py)
+			base = strings.ToLower(base)
+			if !isPinyinBase(base) {
+				continue
+			}
+			for _, reading := range append([]string{base}, fuzzyVariants(base, fuzzy)...) {
+				// lü、nü、lüe、nüe 也可写作 lu、nu、lue、nue
+				spellings := []string{reading}
+				if strings.HasPrefix(reading, "lv") || strings.HasPrefix(reading, "nv") {
+					spellings = append(spellings, reading[:1]+"u"+reading[2:])
+				}
+				for _, spelling := range spellings {
+					if !slices.Contains(char.readings, spelling) {
+						char.readings = append(char.readings, spelling)
+					}
+				}
+			}
+		}
+		target.chars = append(target.chars, char)
+	}
+	return target
+}
+
+// Match 返回与 query 匹配的目标，按目标顺序排列
+// query 可以是汉字、全拼、首字母或混合输入（"bj"、"beij"、"北jing"），不区分大小写，
+// 每个汉字可以用任一读音的全拼或声母匹配，最后一个字也可以只输入读音的开头；
+// 空格和隔音符号可以出现在字之间
+func (m *Matcher) Match(query string) []MatchResult {
+	query = normalizeMatchQuery(query)
+	if query == "" {
+		return nil
+	}
+
+	results := make([]MatchResult, 0)
+	for i, target := range m.targets {
+		if ranges := target.match(query); len(ranges) > 0 {
+			results = append(results, MatchResult{Index: i, Text: target.text, Ranges: ranges})
+		}
+	}
+	return results
+}
+
+// normalizeMatchQuery 转为小写，ü 记为 v，去掉首尾空白和隔音符号
+func normalizeMatchQuery(query string) string {
+	query = strings.ToLower(strings.TrimSpace(query))
+	query = strings.ReplaceAll(query, "ü", "v")
+	return strings.Trim(query, "'")
+}
+
+// match 返回所有不重叠的匹配范围
+func (t matchTarget) match(query string) []MatchRange {
+	ranges := make([]MatchRange, 0)
+	// failed[i][q] 记录从第 i 个字、query 第 q 个字节开始无法完成匹配
+	failed := make([][]bool, len(t.chars)+1)
+	for i := range failed {
+		failed[i] = make([]bool, len(query)+1)
+	}
+
+	for start := 0; start < len(t.chars); {
+		if end, ok := t.matchFrom(query, start, 0, failed); ok {
+			ranges = append(ranges, MatchRange{Start: start, End: end})
+			start = end
+			continue
+		}
+		start++
+	}
+	return ranges
+}
+
+// matchFrom 从第 i 个字、query 第 q 个字节开始匹配，返回匹配结束的字符位置
+func (t matchTarget) matchFrom(query string, i, q int, failed [][]bool) (int, bool) {
+	if q == len(query) {
+		return i, true
+	}
+	if i == len(t.chars) || failed[i][q] {
+		return 0, false
+	}
+
+	char := t.chars[i]
+	r, size := utf8.DecodeRuneInString(query[q:])
+	if r == char.char {
+		if end, ok := t.matchFrom(query, i+1, q+size, failed); ok {
+			return end, true
+		}
+	}
+	// 字之间的空格和隔音符号
+	if (r == ' ' || r == '\'') && q > 0 {
+		if end, ok := t.matchFrom(query, i, q+size, failed); ok {
+			return end, true
+		}
+	}
+
+	rest := query[q:]
+	for _, reading := range char.readings {
+		// 全拼
+		if strings.HasPrefix(rest, reading) {
+			if end, ok := t.matchFrom(query, i+1, q+len(reading), failed); ok {
+				return end, true
+			}
+		}
+		// 最后一个字只输入读音的开头
+		if len(rest) < len(reading) && strings.HasPrefix(reading, rest) {
+			return i + 1, true
+		}
+		// 声母：首字母或 zh、ch、sh
+		for _, initial := range []string{reading[:min(2, len(reading))], reading[:1]} {
+			if len(initial) < len(reading) && (len(initial) == 1 || isAbbreviation(initial)) && strings.HasPrefix(rest, initial) {
+				if end, ok := t.matchFrom(query, i+1, q+len(initial), failed); ok {
+					return end, true
+				}
+			}
+		}
+	}
+
+	failed[i][q] = true
+	return 0, false
+}
+
+// PinyinMatch 判断 query 是否与 target 匹配，返回所有不重叠的匹配范围（字符偏移），不匹配时返回 nil
+// 需要反复匹配同一批目标时请使用 NewMatcher
+func (c *Chinese) PinyinMatch(query, target string) []MatchRange {
+	results := c.NewMatcher([]string{target}).Match(query)
+	if len(results) == 0 {
+		return nil
+	}
+	return results[0].Ranges
+}
+
+// NewMatcher 全局函数：创建拼音匹配器
+func NewMatcher(targets []string) *Matcher {
+	return defaultChinese.NewMatcher(targets)
+}
+
+// NewMatcherWithOptions 全局函数：创建拼音匹配器（使用选项）
+func NewMatcherWithOptions(targets []string, options *MatcherOptions) *Matcher {
+	return defaultChinese.NewMatcherWithOptions(targets, options)
+}
+
+// PinyinMatch 全局函数：拼音匹配
+func PinyinMatch(query, target string) []MatchRange {
+	return defaultChinese.PinyinMatch(query, target)
+}
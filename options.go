@@ -0,0 +1,302 @@
+package zhkit
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// PolyphoneStrategy 多音字处理策略
+type PolyphoneStrategy int
+
+const (
+	// PolyphoneAll 返回全部读音（词组命中时只返回词组读音）
+	PolyphoneAll PolyphoneStrategy = iota
+	// PolyphoneFirst 每个字只返回一个读音（词组读音或第一个读音）
+	PolyphoneFirst
+)
+
+// CharHandling 非中文字符、未知汉字的处理方式
+type CharHandling int
+
+const (
+	// CharKeep 保留原字符
+	CharKeep CharHandling = iota
+	// CharRemove 删除
+	CharRemove
+	// CharReplace 替换为 PinyinOptions.Replacement
+	CharReplace
+)
+
+// PinyinOptions 拼音转换选项
+type PinyinOptions struct {
+	Mode                 ConvertMode       // 转换模式，可组合，默认为 ModePinyin
+	Separator            string            // 分隔符，默认为空格，仅字符串结果使用
+	SplitNonChinese      bool              // 是否分割非中文字符，为 false 时连续的字母和数字作为一个整体
+	UmlautReplacement    string            // ModePinyinASCII 中 ü 的写法，为空时使用实例设置（默认 v）
+	Polyphone            PolyphoneStrategy // 多音字处理策略
+	NonChinese           CharHandling      // 非中文字符的处理方式
+	Unknown              CharHandling      // 没有拼音数据的汉字的处理方式
+	Replacement          string            // CharReplace 时使用的替换字符串
+	IsName               bool              // 是否为姓名，为 true 时开头的姓氏使用姓氏读音
+	ToneSandhi           bool              // 是否按实际发音变调（三声变调、"一"和"不"变调），影响带声调的模式
+	NeutralReduplication bool              // 变调时叠词的第二个字是否读轻声，如 妈妈 māma
+	Erhua                bool              // 是否将儿化的"儿"合并到前一个音节，如 一点儿 yīdiǎnr
+	ToneStyle            ToneStyle         // ModeWadeGiles、ModeYale、ModeIPA 的声调写法，默认为数字声调
+}
+
+// pinyinUnit 拼音转换单元：一个汉字或一段非中文字符
+type pinyinUnit struct {
+	text    string
+	pinyins []string // 汉字的候选读音（带声调），非中文为 nil
+	start   int      // 在原文中的起始字符（rune）位置
+	end     int      // 在原文中的结束字符（rune）位置（不含）
+	isHan   bool     // 是否为汉字（包括没有拼音数据的汉字）
+	phrase  bool     // 读音是否来自词组词典
+}
+
+// pinyinConverter 转换模式对应的结果字段和转换函数
+type pinyinConverter struct {
+	mode    ConvertMode
+	field   func(*PinyinResult) *[][]string
+	convert func(*Chinese, string, *PinyinOptions) string
+}
+
+// pinyinConverters 所有转换模式，顺序即输出顺序
+var pinyinConverters = []pinyinConverter{
+	{
+		ModePinyin,
+		func(r *PinyinResult) *[][]string { return &r.Pinyin },
+		func(c *Chinese, py string, _ *PinyinOptions) string { return c.removeTone(py) },
+	},
+	{
+		ModePinyinFirst,
+		func(r *PinyinResult) *[][]string { return &r.PinyinFirst },
+		func(c *Chinese, py string, _ *PinyinOptions) string { return c.firstLetter(py) },
+	},
+	{
+		ModePinyinSound,
+		func(r *PinyinResult) *[][]string { return &r.PinyinSound },
+		func(c *Chinese, py string, _ *PinyinOptions) string { return c.addToneMarks(py) },
+	},
+	{
+		ModePinyinSoundNumber,
+		func(r *PinyinResult) *[][]string { return &r.PinyinSoundNumber },
+		func(c *Chinese, py string, _ *PinyinOptions) string { return c.addToneNumbers(py) },
+	},
+	{
+		ModePinyinASCII,
+		func(r *PinyinResult) *[][]string { return &r.PinyinASCII },
+		func(c *Chinese, py string, o *PinyinOptions) string {
+			return c.toASCIIPinyin(py, o.UmlautReplacement)
+		},
+	},
+	{
+		ModePinyinInitial,
+		func(r *PinyinResult) *[][]string { return &r.PinyinInitial },
+		func(c *Chinese, py string, _ *PinyinOptions) string { return c.pinyinInitial(py) },
+	},
+	{
+		ModeZhuyin,
+		func(r *PinyinResult) *[][]string { return &r.Zhuyin },
+		func(c *Chinese, py string, _ *PinyinOptions) string { return c.toZhuyin(py) },
+	},
+	{
+		ModeWadeGiles,
+		func(r *PinyinResult) *[][]string { return &r.WadeGiles },
+		func(c *Chinese, py string, o *PinyinOptions) string { return c.toWadeGiles(py, o.ToneStyle) },
+	},
+	{
+		ModeYale,
+		func(r *PinyinResult) *[][]string { return &r.Yale },
+		func(c *Chinese, py string, o *PinyinOptions) string { return c.toYale(py, o.ToneStyle) },
+	},
+	{
+		ModeIPA,
+		func(r *PinyinResult) *[][]string { return &r.IPA },
+		func(c *Chinese, py string, o *PinyinOptions) string { return c.toIPA(py, o.ToneStyle) },
+	},
+}
+
+// findPinyinConverter 查找单一转换模式对应的转换器
+func findPinyinConverter(mode ConvertMode) (pinyinConverter, error) {
+	for _, converter := range pinyinConverters {
+		if converter.mode == mode {
+			return converter, nil
+		}
+	}
+	return pinyinConverter{}, fmt.Errorf("不支持的转换模式: %d，只能指定一种模式", mode)
+}
+
+// normalizePinyinOptions 复制选项并填充默认值
+func (c *Chinese) normalizePinyinOptions(options *PinyinOptions) *PinyinOptions {
+	normalized := PinyinOptions{}
+	if options != nil {
+		normalized = *options
+	}
+	if normalized.Mode == 0 {
+		normalized.Mode = ModePinyin
+	}
+	if normalized.Separator == "" {
+		normalized.Separator = " "
+	}
+	if normalized.UmlautReplacement == "" {
+		normalized.UmlautReplacement = c.umlautReplace
+	}
+	return &normalized
+}
+
+// ToPinyinWithOptions 汉字转拼音（使用选项）
+// options 为 nil 时使用默认选项
+func (c *Chinese) ToPinyinWithOptions(text string, options *PinyinOptions) (*PinyinResult, error) {
+	if text == "" {
+		return &PinyinResult{}, nil
+	}
+
+	options = c.normalizePinyinOptions(options)
+	units := c.pinyinUnits(text, options)
+	result := &PinyinResult{}
+
+	for _, converter := range pinyinConverters {
+		if options.Mode&converter.mode == 0 {
+			continue
+		}
+
+		rows := make([][]string, 0, len(units))
+		for _, unit := range units {
+			if unit.pinyins == nil {
+				// 非中文字符
+				rows = append(rows, []string{unit.text})
+				continue
+			}
+
+			rows = append(rows, c.convertReadings(unit.pinyins, converter, options))
+		}
+		*converter.field(result) = rows
+	}
+
+	return result, nil
+}
+
+// convertReadings 按转换模式转换一个字的全部读音，去掉转换后重复的结果（如无调模式下的 hǎo、hào）
+func (c *Chinese) convertReadings(pinyins []string, converter pinyinConverter, options *PinyinOptions) []string {
+	values := make([]string, 0, len(pinyins))
+	for _, py := range pinyins {
+		if value := converter.convert(c, py, options); !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// ToPinyinStringWithOptions 汉字转拼音（使用选项，返回字符串）
+// 按分隔符拼接每个字的拼音，多音字取第一个读音，空白字符不输出
+// 转换结果为空的字（如 ModePinyinInitial 下的零声母音节）保留空位，使结果与原文逐字对应
+// options.Mode 只能指定一种转换模式
+func (c *Chinese) ToPinyinStringWithOptions(text string, options *PinyinOptions) (string, error) {
+	options = c.normalizePinyinOptions(options)
+	converter, err := findPinyinConverter(options.Mode)
+	if err != nil {
+		return "", err
+	}
+
+	parts := make([]string, 0)
+	for _, unit := range c.pinyinUnits(text, options) {
+		if unit.pinyins == nil {
+			if strings.TrimSpace(unit.text) != "" {
+				parts = append(parts, unit.text)
+			}
+			continue
+		}
+		parts = append(parts, converter.convert(c, unit.pinyins[0], options))
+	}
+
+	return strings.Join(parts, options.Separator), nil
+}
+
+// pinyinUnits 将文本切分为拼音转换单元
+// 汉字按词组或单字确定读音；非中文字符在 SplitNonChinese 为 false 时将连续的字母和数字合并
+func (c *Chinese) pinyinUnits(text string, options *PinyinOptions) []pinyinUnit {
+	runes := []rune(text)
+	units := make([]pinyinUnit, 0, len(runes))
+
+	// 先按词组确定多音字读音，未匹配的字使用单字读音
+	// 姓名中开头的姓氏使用姓氏读音，名字部分再按词组匹配
+	var surname []string
+	if options.IsName {
+		surname = c.matchSurname(runes)
+	}
+	phrasePinyins := append(slices.Clone(surname), c.matchPhrases(runes[len(surname):])...)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if phrasePinyins[i] != "" {
+			units = append(units, pinyinUnit{text: string(r), pinyins: []string{phrasePinyins[i]}, start: i, end: i + 1, isHan: true, phrase: true})
+			continue
+		}
+		if pinyins, exists := c.pinyinData[r]; exists {
+			if options.Polyphone == PolyphoneFirst {
+				pinyins = pinyins[:1]
+			}
+			units = append(units, pinyinUnit{text: string(r), pinyins: pinyins, start: i, end: i + 1, isHan: true})
+			continue
+		}
+
+		// 没有拼音数据的汉字
+		if unicode.Is(unicode.Han, r) {
+			if unit, keep := handleChar(string(r), options.Unknown, options.Replacement); keep {
+				unit.start, unit.end, unit.isHan = i, i+1, true
+				units = append(units, unit)
+			}
+			continue
+		}
+
+		// 非中文字符
+		end := i + 1
+		if !options.SplitNonChinese && isAlphanumeric(r) {
+			for end < len(runes) && isAlphanumeric(runes[end]) {
+				if _, exists := c.pinyinData[runes[end]]; exists {
+					break
+				}
+				end++
+			}
+		}
+		if unit, keep := handleChar(string(runes[i:end]), options.NonChinese, options.Replacement); keep {
+			unit.start, unit.end = i, end
+			units = append(units, unit)
+		}
+		i = end - 1
+	}
+
+	if options.ToneSandhi {
+		c.applyToneSandhi(units, options)
+	}
+	if options.Erhua {
+		units = c.mergeErhua(units)
+	}
+
+	return units
+}
+
+// handleChar 按处理方式生成非拼音单元，返回 false 表示删除
+func handleChar(text string, handling CharHandling, replacement string) (pinyinUnit, bool) {
+	switch handling {
+	case CharRemove:
+		return pinyinUnit{}, false
+	case CharReplace:
+		return pinyinUnit{text: replacement}, true
+	default:
+		return pinyinUnit{text: text}, true
+	}
+}
+
+// ToPinyinWithOptions 全局函数：汉字转拼音（使用选项）
+func ToPinyinWithOptions(text string, options *PinyinOptions) (*PinyinResult, error) {
+	return defaultChinese.ToPinyinWithOptions(text, options)
+}
+
+// ToPinyinStringWithOptions 全局函数：汉字转拼音（使用选项，返回字符串）
+func ToPinyinStringWithOptions(text string, options *PinyinOptions) (string, error) {
+	return defaultChinese.ToPinyinStringWithOptions(text, options)
+}
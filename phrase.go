@@ -0,0 +1,101 @@
+package zhkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// parsePhraseData 解析词组拼音数据
+// 格式: {"银行": "yín háng"}，拼音之间用空格分隔
+func (c *Chinese) parsePhraseData(data map[string]string) error {
+	for phrase, pinyin := range data {
+		if err := c.AddPhrase(phrase, strings.Fields(pinyin)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddPhrase 添加词组读音，用于多音字消歧
+// phrase: 词组，如 "银行"
+// pinyins: 每个字的读音，如 "yín", "háng"，也支持数字声调写法 "yin2", "hang2"
+// 该方法会修改实例数据，应在并发使用前调用
+func (c *Chinese) AddPhrase(phrase string, pinyins ...string) error {
+	runes := []rune(phrase)
+	if len(runes) < 2 {
+		return fmt.Errorf("词组至少需要两个字: %s", phrase)
+	}
+	if len(runes) != len(pinyins) {
+		return fmt.Errorf("词组 %s 的字数与拼音数量不一致", phrase)
+	}
+
+	readings := make([]string, len(pinyins))
+	for i, py := range pinyins {
+		readings[i] = c.addToneMarks(py)
+	}
+
+	c.phraseData[phrase] = readings
+	c.resetIndexes()
+	if len(runes) > c.maxPhraseLen {
+		c.maxPhraseLen = len(runes)
+	}
+	return nil
+}
+
+// LoadPhraseData 加载词组拼音数据
+func (c *Chinese) LoadPhraseData(dataPath string) error {
+	filePath := filepath.Join(dataPath, "phrasesData.json")
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("无法打开词组数据文件: %v", err)
+	}
+	defer file.Close()
+
+	var data map[string]string
+	decoder := json.NewDecoder(file)
+	if err := decoder.Decode(&data); err != nil {
+		return fmt.Errorf("解析词组数据失败: %v", err)
+	}
+
+	return c.parsePhraseData(data)
+}
+
+// matchPhrases 按正向最大匹配查找词组读音
+// 返回与 runes 等长的切片，未被词组覆盖的位置为空字符串
+func (c *Chinese) matchPhrases(runes []rune) []string {
+	matched := make([]string, len(runes))
+	if len(c.phraseData) == 0 {
+		return matched
+	}
+
+	for i := 0; i < len(runes); {
+		length := c.maxPhraseLen
+		if length > len(runes)-i {
+			length = len(runes) - i
+		}
+
+		found := false
+		for ; length >= 2; length-- {
+			if readings, exists := c.phraseData[string(runes[i:i+length])]; exists {
+				copy(matched[i:i+length], readings)
+				i += length
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			i++
+		}
+	}
+
+	return matched
+}
+
+// AddPhrase 全局函数：添加词组读音
+func AddPhrase(phrase string, pinyins ...string) error {
+	return defaultChinese.AddPhrase(phrase, pinyins...)
+}
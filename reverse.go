@@ -0,0 +1,219 @@
+package zhkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// hanziEntry 拼音反查索引中的一个读音
+type hanziEntry struct {
+	char  rune
+	tone  int // 声调，轻声为 5
+	order int // 该读音在字的读音中的位置，0 为首选读音
+}
+
+// resetIndexes 拼音、字频或词库数据变化后清空反查索引和组词索引，下次查询时重建
+func (c *Chinese) resetIndexes() {
+	c.indexOnce = &sync.Once{}
+}
+
+// buildIndexes 构建拼音反查索引和组词索引
+func (c *Chinese) buildIndexes() {
+	c.hanziIndexData = c.buildHanziIndex()
+	c.lexiconData = c.buildLexicon(c.hanziIndexData)
+}
+
+// parseFrequencyData 解析字频数据
+// 格式: {"的": 1, "一": 2}，数字为字频排名，越小越常用
+func (c *Chinese) parseFrequencyData(data map[string]int) error {
+	for char, rank := range data {
+		runes := []rune(char)
+		if len(runes) != 1 || rank <= 0 {
+			continue
+		}
+		c.charRank[runes[0]] = rank
+	}
+	c.resetIndexes()
+	return nil
+}
+
+// LoadFrequencyData 加载字频数据，用于拼音反查结果排序
+func (c *Chinese) LoadFrequencyData(dataPath string) error {
+	filePath := filepath.Join(dataPath, "frequencyData.json")
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("无法打开字频数据文件: %v", err)
+	}
+	defer file.Close()
+
+	var data map[string]int
+	decoder := json.NewDecoder(file)
+	if err := decoder.Decode(&data); err != nil {
+		return fmt.Errorf("解析字频数据失败: %v", err)
+	}
+
+	return c.parseFrequencyData(data)
+}
+
+// compareHanzi 按常用程度比较两个字：有字频排名的按排名，其余按 charsData.json 的常用字标记
+func (c *Chinese) compareHanzi(a, b rune) int {
+	rankA, rankedA := c.charRank[a]
+	rankB, rankedB := c.charRank[b]
+	switch {
+	case rankedA && rankedB:
+		return rankA - rankB
+	case rankedA:
+		return -1
+	case rankedB:
+		return 1
+	}
+	return c.charWeight[b] - c.charWeight[a]
+}
+
+// compareHanziEntry 按读音的常用程度比较两个反查条目
+// 都有字频排名时，排名按读音位置加权（排名 ×(位置+1)³），使常用字生僻的次要读音（如 么 读 ma）排在以该音为首选读音的常用字之后，
+// 而 的（de）、都（dōu）这类极常用字的次要读音仍然靠前；否则按 compareHanzi 比较
+func (c *Chinese) compareHanziEntry(a, b hanziEntry) int {
+	rankA, rankedA := c.charRank[a.char]
+	rankB, rankedB := c.charRank[b.char]
+	if rankedA && rankedB {
+		return readingRank(rankA, a.order) - readingRank(rankB, b.order)
+	}
+	return c.compareHanzi(a.char, b.char)
+}
+
+// readingRank 按读音位置加权的字频排名
+func readingRank(rank, order int) int {
+	weight := order + 1
+	return rank * weight * weight * weight
+}
+
+// hanziIndex 返回拼音（无调，ü 记为 v）到汉字的反查索引
+func (c *Chinese) hanziIndex() map[string][]hanziEntry {
+	c.indexOnce.Do(c.buildIndexes)
+	return c.hanziIndexData
+}
+
+// buildHanziIndex 构建拼音反查索引
+// 每个音节的汉字按读音的常用程度（见 compareHanziEntry）、读音位置（首选读音在前）、码位排序
+func (c *Chinese) buildHanziIndex() map[string][]hanziEntry {
+	index := make(map[string][]hanziEntry)
+	for char, pinyins := range c.pinyinData {
+		for order, py := range pinyins {
+			base, tone := c.splitTone(py)
+			base = strings.ToLower(base)
+			if !isPinyinBase(base) {
+				continue
+			}
+			index[base] = append(index[base], hanziEntry{char: char, tone: tone, order: order})
+		}
+	}
+
+	for _, entries := range index {
+		slices.SortFunc(entries, func(a, b hanziEntry) int {
+			if cmp := c.compareHanziEntry(a, b); cmp != 0 {
+				return cmp
+			}
+			if a.order != b.order {
+				return a.order - b.order
+			}
+			return int(a.char - b.char)
+		})
+	}
+	return index
+}
+
+// isPinyinBase 判断是否为无调拼音（只含小写字母）
+func isPinyinBase(base string) bool {
+	if base == "" {
+		return false
+	}
+	for _, r := range base {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupHanzi 按无调拼音和声调查找汉字，tone 为 0 时不限声调
+// 同一个字只返回一次
+func (c *Chinese) lookupHanzi(base string, tone int) []rune {
+	entries := c.hanziIndex()[base]
+	chars := make([]rune, 0, len(entries))
+	seen := make(map[rune]bool, len(entries))
+	for _, entry := range entries {
+		if (tone != 0 && entry.tone != tone) || seen[entry.char] {
+			continue
+		}
+		seen[entry.char] = true
+		chars = append(chars, entry.char)
+	}
+	return chars
+}
+
+// parsePinyinQuery 解析反查使用的拼音，返回无调拼音（ü 记为 v，lue、nue 还原为 lve、nve）和声调
+// 没有声调符号和数字声调时 tone 为 0
+func (c *Chinese) parsePinyinQuery(pinyin string) (string, int) {
+	pinyin = strings.ToLower(strings.TrimSpace(pinyin))
+	base, tone := c.splitTone(pinyin)
+	if !c.hasToneMark(pinyin) {
+		tone = 0
+	}
+	return umlautSyllable(strings.ToLower(base)), tone
+}
+
+// HanziOptions 拼音反查汉字选项
+type HanziOptions struct {
+	Fuzzy FuzzyRule // 同时返回按哪些模糊音规则相近的字，为 0 时不启用
+}
+
+// PinyinToHanzi 拼音反查汉字
+// 支持声调符号（zhōng）、数字声调（zhong1）和无调拼音（zhong，返回所有声调的字），ü 可写作 v，lüe、nüe 也可写作 lue、nue
+// 结果按字频排序，没有字频数据的字按常用字标记排在后面
+func (c *Chinese) PinyinToHanzi(pinyin string) ([]string, error) {
+	return c.PinyinToHanziWithOptions(pinyin, nil)
+}
+
+// PinyinToHanziWithOptions 拼音反查汉字（使用选项）
+// 启用模糊音时，模糊音匹配的字排在读音完全相同的字之后
+func (c *Chinese) PinyinToHanziWithOptions(pinyin string, options *HanziOptions) ([]string, error) {
+	if options == nil {
+		options = &HanziOptions{}
+	}
+
+	base, tone := c.parsePinyinQuery(pinyin)
+	if !isPinyinBase(base) {
+		return nil, fmt.Errorf("无法识别的拼音: %s", pinyin)
+	}
+
+	chars := c.lookupHanzi(base, tone)
+	for _, variant := range fuzzyVariants(base, options.Fuzzy) {
+		for _, char := range c.lookupHanzi(variant, tone) {
+			if !slices.Contains(chars, char) {
+				chars = append(chars, char)
+			}
+		}
+	}
+
+	results := make([]string, len(chars))
+	for i, char := range chars {
+		results[i] = string(char)
+	}
+	return results, nil
+}
+
+// PinyinToHanzi 全局函数：拼音反查汉字
+func PinyinToHanzi(pinyin string) ([]string, error) {
+	return defaultChinese.PinyinToHanzi(pinyin)
+}
+
+// PinyinToHanziWithOptions 全局函数：拼音反查汉字（使用选项）
+func PinyinToHanziWithOptions(pinyin string, options *HanziOptions) ([]string, error) {
+	return defaultChinese.PinyinToHanziWithOptions(pinyin, options)
+}
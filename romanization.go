@@ -0,0 +1,261 @@
+package zhkit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToneStyle 威妥玛拼音、耶鲁拼音的声调写法
+type ToneStyle int
+
+const (
+	// ToneNumber 数字声调，如 ch'ing2
+	ToneNumber ToneStyle = iota
+	// ToneSuperscript 上标数字声调，如 ch'ing²
+	ToneSuperscript
+	// ToneNone 不标声调，如 ch'ing
+	ToneNone
+	// ToneLetter 赵元任五度调符号，如 ʈʂʰiŋ˥，仅用于 ModeIPA，其他模式按数字声调输出
+	ToneLetter
+)
+
+// toneSuperscripts 上标数字 0–9，下标即数字
+var toneSuperscripts = []string{"⁰", "¹", "²", "³", "⁴", "⁵", "⁶", "⁷", "⁸", "⁹"}
+
+// wadeGilesInitials 声母与威妥玛拼音对照
+var wadeGilesInitials = map[string]string{
+	"b": "p", "p": "p'", "m": "m", "f": "f",
+	"d": "t", "t": "t'", "n": "n", "l": "l",
+	"g": "k", "k": "k'", "h": "h",
+	"j": "ch", "q": "ch'", "x": "hs",
+	"zh": "ch", "ch": "ch'", "sh": "sh", "r": "j",
+	"z": "ts", "c": "ts'", "s": "s",
+}
+
+// wadeGilesFinals 韵母（标准写法）与威妥玛拼音对照
+var wadeGilesFinals = map[string]string{
+	"a": "a", "o": "o", "e": "ê",
+	"ai": "ai", "ei": "ei", "ao": "ao", "ou": "ou",
+	"an": "an", "en": "ên", "ang": "ang", "eng": "êng", "ong": "ung", "er": "êrh",
+	"i": "i", "ia": "ia", "io": "io", "ie": "ieh", "iao": "iao", "iou": "iu",
+	"ian": "ien", "in": "in", "iang": "iang", "ing": "ing", "iong": "iung",
+	"u": "u", "ua": "ua", "uo": "uo", "uai": "uai", "uei": "ui",
+	"uan": "uan", "uen": "un", "uang": "uang", "ueng": "ung",
+	"v": "ü", "ve": "üeh", "van": "üan", "vn": "ün",
+}
+
+// yaleInitials 声母与耶鲁拼音对照
+var yaleInitials = map[string]string{
+	"b": "b", "p": "p", "m": "m", "f": "f",
+	"d": "d", "t": "t", "n": "n", "l": "l",
+	"g": "g", "k": "k", "h": "h",
+	"j": "j", "q": "ch", "x": "s",
+	"zh": "j", "ch": "ch", "sh": "sh", "r": "r",
+	"z": "dz", "c": "ts", "s": "s",
+}
+
+// yaleFinals 韵母（标准写法）与耶鲁拼音对照
+var yaleFinals = map[string]string{
+	"a": "a", "o": "o", "e": "e",
+	"ai": "ai", "ei": "ei", "ao": "au", "ou": "ou",
+	"an": "an", "en": "en", "ang": "ang", "eng": "eng", "ong": "ung", "er": "er",
+	"i": "i", "ia": "ya", "io": "yo", "ie": "ye", "iao": "yau", "iou": "you",
+	"ian": "yan", "in": "in", "iang": "yang", "ing": "ing", "iong": "yung",
+	"u": "u", "ua": "wa", "uo": "wo", "uai": "wai", "uei": "wei",
+	"uan": "wan", "uen": "wun", "uang": "wang", "ueng": "weng",
+	"v": "yu", "ve": "ywe", "van": "ywan", "vn": "yun",
+}
+
+// 儿化韵尾
+const (
+	wadeGilesErhua = "rh"
+	yaleErhua      = "r"
+)
+
+// isSyllabicNasal 判断是否为自成音节的鼻音（m、n、ng、hm、hng），两种拼写法都按拼音原样书写
+func isSyllabicNasal(initial, final string) bool {
+	switch initial {
+	case "":
+		return final == "m" || final == "n" || final == "ng"
+	case "h":
+		return final == "m" || final == "ng"
+	}
+	return false
+}
+
+// wadeGilesSpelling 按威妥玛拼音拼写规则拼写音节
+func wadeGilesSpelling(initial, final string) string {
+	if isSyllabicNasal(initial, final) {
+		return initial + final
+	}
+	if initial == "" {
+		switch {
+		case final == "i" || final == "e" || !strings.ContainsAny(final[:1], "iuv"):
+			return wadeGilesFinals[final]
+		case final == "iou":
+			return "yu"
+		case final == "in" || final == "ing":
+			return "y" + final
+		case strings.HasPrefix(final, "i"):
+			return "y" + wadeGilesFinals[final][1:]
+		case final == "u":
+			return "wu"
+		case strings.HasPrefix(final, "u"):
+			return "w" + final[1:]
+		default:
+			return "y" + wadeGilesFinals[final]
+		}
+	}
+
+	spelled := wadeGilesFinals[final]
+	switch {
+	case final == "":
+		// zhi、chi、shi、ri 写作 -ih；zi、ci、si 写作 tzŭ、tz'ŭ、ssŭ
+		switch initial {
+		case "z":
+			return "tzŭ"
+		case "c":
+			return "tz'ŭ"
+		case "s":
+			return "ssŭ"
+		}
+		spelled = "ih"
+	case final == "e" && (initial == "g" || initial == "k" || initial == "h"):
+		spelled = "o"
+	case final == "uo" && initial != "g" && initial != "k" && initial != "h" && initial != "sh":
+		spelled = "o"
+	case final == "uei" && (initial == "g" || initial == "k"):
+		spelled = "uei"
+	}
+	return wadeGilesInitials[initial] + spelled
+}
+
+// yaleSpelling 按耶鲁拼音拼写规则拼写音节
+func yaleSpelling(initial, final string) string {
+	if isSyllabicNasal(initial, final) {
+		return initial + final
+	}
+	if initial == "" {
+		switch final {
+		case "i", "in", "ing":
+			return "y" + final
+		case "u":
+			return "wu"
+		case "uen":
+			return "wen"
+		}
+		return yaleFinals[final]
+	}
+
+	spelled := yaleFinals[final]
+	switch {
+	case final == "":
+		// zhi、chi、shi 写作 jr、chr、shr，ri 写作 r；zi、ci、si 写作 dz、tsz、sz
+		switch initial {
+		case "r", "z":
+			spelled = ""
+		case "c", "s":
+			spelled = "z"
+		default:
+			spelled = "r"
+		}
+	case final == "o" && strings.Contains("bpmf", initial):
+		spelled = "wo"
+	case initial == "x" && strings.HasPrefix(spelled, "i"):
+		// xi、xin、xing 写作 syi、syin、sying
+		spelled = "y" + spelled
+	}
+	return yaleInitials[initial] + spelled
+}
+
+// romanizeSyllable 将一个带声调的拼音音节按拼写规则转写
+// 只接受拼音音节表中的音节、自成音节的鼻音以及它们的儿化形式（如 diǎnr），
+// 儿化音节在韵母后加 erhua，轻声不标声调
+func (c *Chinese) romanizeSyllable(pinyin string, style ToneStyle, spelling func(initial, final string) string, erhua string) (string, bool) {
+	base, tone := c.splitTone(pinyin)
+	base = strings.ToLower(base)
+
+	suffix := ""
+	if len(base) > 2 && strings.HasSuffix(base, "r") {
+		base, suffix = base[:len(base)-1], erhua
+	}
+
+	initial, final, ok := splitSyllable(base)
+	if !ok {
+		return "", false
+	}
+	if !isSyllabicNasal(initial, final) && !c.isValidPinyin(strings.Replace(base, "ve", "ue", 1)) {
+		return "", false
+	}
+
+	return spelling(initial, final) + suffix + romanizationTone(tone, style), true
+}
+
+// romanizationTone 按声调写法返回音节后的声调标记，轻声不标
+func romanizationTone(tone int, style ToneStyle) string {
+	if tone < 1 || tone > 4 {
+		return ""
+	}
+	switch style {
+	case ToneSuperscript:
+		return toneSuperscripts[tone]
+	case ToneNone:
+		return ""
+	default:
+		return fmt.Sprint(tone)
+	}
+}
+
+// romanize 将以空白分隔的拼音音节逐个转写
+func (c *Chinese) romanize(pinyin string, style ToneStyle, spelling func(initial, final string) string, erhua string) (string, error) {
+	syllables := strings.Fields(pinyin)
+	results := make([]string, len(syllables))
+
+	for i, syllable := range syllables {
+		romanized, ok := c.romanizeSyllable(syllable, style, spelling, erhua)
+		if !ok {
+			return "", fmt.Errorf("无法识别的拼音: %s", syllable)
+		}
+		results[i] = romanized
+	}
+
+	return strings.Join(results, " "), nil
+}
+
+// toWadeGiles 将带声调的拼音转换为威妥玛拼音，无法识别时返回原文
+func (c *Chinese) toWadeGiles(pinyin string, style ToneStyle) string {
+	if romanized, ok := c.romanizeSyllable(pinyin, style, wadeGilesSpelling, wadeGilesErhua); ok {
+		return romanized
+	}
+	return pinyin
+}
+
+// toYale 将带声调的拼音转换为耶鲁拼音，无法识别时返回原文
+func (c *Chinese) toYale(pinyin string, style ToneStyle) string {
+	if romanized, ok := c.romanizeSyllable(pinyin, style, yaleSpelling, yaleErhua); ok {
+		return romanized
+	}
+	return pinyin
+}
+
+// PinyinToWadeGiles 拼音转威妥玛拼音
+// 输入以空白分隔的音节，支持声调符号（qīng）、数字声调（qing1）和无调拼音
+func (c *Chinese) PinyinToWadeGiles(pinyin string, style ToneStyle) (string, error) {
+	return c.romanize(pinyin, style, wadeGilesSpelling, wadeGilesErhua)
+}
+
+// PinyinToYale 拼音转耶鲁拼音
+// 输入以空白分隔的音节，支持声调符号（qīng）、数字声调（qing1）和无调拼音
+func (c *Chinese) PinyinToYale(pinyin string, style ToneStyle) (string, error) {
+	return c.romanize(pinyin, style, yaleSpelling, yaleErhua)
+}
+
+// PinyinToWadeGiles 全局函数：拼音转威妥玛拼音
+func PinyinToWadeGiles(pinyin string, style ToneStyle) (string, error) {
+	return defaultChinese.PinyinToWadeGiles(pinyin, style)
+}
+
+// PinyinToYale 全局函数：拼音转耶鲁拼音
+func PinyinToYale(pinyin string, style ToneStyle) (string, error) {
+	return defaultChinese.PinyinToYale(pinyin, style)
+}
@@ -0,0 +1,102 @@
+package zhkit
+
+import "slices"
+
+// applyToneSandhi 按普通话变调规则调整读音的声调
+// 规则：
+//   - 叠词第二个字读轻声（需开启 NeutralReduplication），如 妈妈 māma
+//   - "一"、"不"夹在相同的字之间读轻声，如 看一看、好不好
+//   - "一"在四声、轻声前读二声，在一、二、三声前读四声，序数（第一）、数字中（一九八四、十一）及词尾不变
+//   - "不"在四声前读二声
+//   - 连续的三声，除最后一个外都读二声，如 你好 níhǎo
+//
+// 多音字按第一个读音判断上下文，只调整与第一个读音声调相同的读音
+func (c *Chinese) applyToneSandhi(units []pinyinUnit, options *PinyinOptions) {
+	tones := make([]int, len(units))
+	for i, unit := range units {
+		if unit.pinyins != nil {
+			_, tones[i] = c.splitTone(unit.pinyins[0])
+		}
+	}
+
+	isHan := func(i int) bool {
+		return i >= 0 && i < len(units) && units[i].pinyins != nil
+	}
+	adjacent := func(i, j int) bool {
+		return isHan(i) && isHan(j) && units[i].end == units[j].start
+	}
+
+	sandhi := make([]int, len(tones))
+	copy(sandhi, tones)
+
+	// 叠词轻声
+	if options.NeutralReduplication {
+		for i := 1; i < len(units); i++ {
+			if adjacent(i-1, i) && units[i].text == units[i-1].text && units[i].text != "一" {
+				sandhi[i] = neutralTone
+			}
+		}
+	}
+
+	// "一"、"不"变调
+	for i, unit := range units {
+		if !isHan(i) || (unit.text != "一" && unit.text != "不") {
+			continue
+		}
+
+		switch {
+		case adjacent(i-1, i) && adjacent(i, i+1) && units[i-1].text == units[i+1].text:
+			sandhi[i] = neutralTone
+		case unit.text == "一" && adjacent(i-1, i) && (units[i-1].text == "第" || isNumeralDigit(units[i-1].text)):
+			// 序数和数字中保持原调（第一、十一）
+		case unit.text == "一" && adjacent(i, i+1) && isNumeralDigit(units[i+1].text):
+			// 逐位读的数字保持原调（一九八四），一百、一千等仍按规则变调
+		case !adjacent(i, i+1):
+			// 词尾保持原调
+		case unit.text == "一" && tones[i] == 1:
+			if tones[i+1] == 4 || tones[i+1] == neutralTone {
+				sandhi[i] = 2
+			} else {
+				sandhi[i] = 4
+			}
+		case unit.text == "不" && tones[i] == 4 && tones[i+1] == 4:
+			sandhi[i] = 2
+		}
+	}
+
+	// 三声变调
+	for i := 0; i < len(units); i++ {
+		if sandhi[i] != 3 {
+			continue
+		}
+		end := i
+		for adjacent(end, end+1) && sandhi[end+1] == 3 {
+			end++
+		}
+		for j := i; j < end; j++ {
+			sandhi[j] = 2
+		}
+		i = end
+	}
+
+	for i := range units {
+		if sandhi[i] == tones[i] {
+			continue
+		}
+
+		pinyins := make([]string, len(units[i].pinyins))
+		for j, py := range units[i].pinyins {
+			base, tone := c.splitTone(py)
+			if tone == tones[i] {
+				py = c.markTone(base, sandhi[i])
+			}
+			pinyins[j] = py
+		}
+		units[i].pinyins = pinyins
+	}
+}
+
+// isNumeralDigit 判断是否为中文数字的数位（〇、零至九、十），不含百、千、万等单位
+func isNumeralDigit(text string) bool {
+	return text == "〇" || text == "十" || slices.Contains(chineseNumbers, text)
+}
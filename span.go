@@ -0,0 +1,204 @@
+package zhkit
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// SpanKind 混合内容分词的片段类型
+type SpanKind int
+
+const (
+	// SpanPinyin 拼音音节（可带声调符号或数字声调）
+	SpanPinyin SpanKind = iota
+	// SpanLetters 无法切分为拼音的字母
+	SpanLetters
+	// SpanDigits 数字
+	SpanDigits
+	// SpanUnknown 其他字符（汉字、标点、符号等）
+	SpanUnknown
+)
+
+// PinyinSpan 混合内容分词的片段
+type PinyinSpan struct {
+	Kind  SpanKind `json:"kind"`  // 片段类型
+	Text  string   `json:"text"`  // 原文
+	Start int      `json:"start"` // 起始字节偏移
+	End   int      `json:"end"`   // 结束字节偏移（不含）
+}
+
+// spanMinSyllableLetters 字母不能完整切分为拼音时，与剩余字母相邻的连续音节至少要有的字母数
+// 不足时并入剩余字母，避免从 iphone、hello 这类英文单词中零散地切出 o、ne、he 等音节；
+// 前后都是剩余字母的连续音节（如 istanbul 中的 tan bu）总是并入剩余字母
+const spanMinSyllableLetters = 4
+
+// spanCost 字母段切分方案的代价
+type spanCost struct {
+	uncovered int // 没有切分为拼音的字母数
+	syllables int // 音节数
+	rank      int // 各音节的字频排名之和
+}
+
+// less 依次按剩余字母数、音节数、字频排名比较
+func (a spanCost) less(b spanCost) bool {
+	if a.uncovered != b.uncovered {
+		return a.uncovered < b.uncovered
+	}
+	if a.syllables != b.syllables {
+		return a.syllables < b.syllables
+	}
+	return a.rank < b.rank
+}
+
+// SplitPinyinSpans 混合内容拼音分词，返回带类型和原文位置的片段
+// 连续的字母能完整切分为拼音时按 SplitPinyinWithOptions 的最优方案切分；
+// 否则尽可能多地切分为拼音音节，剩余的字母为 SpanLetters（与剩余字母相邻、合计不足 4 个字母的连续音节，
+// 以及前后都是剩余字母的连续音节也并入 SpanLetters）；
+// 紧跟在音节后的单个数字 1-5 作为该音节的数字声调，其余数字为 SpanDigits；
+// 空白和隔音符号是分隔符，不生成片段；其他字符为 SpanUnknown
+func (c *Chinese) SplitPinyinSpans(text string) ([]PinyinSpan, error) {
+	spans := make([]PinyinSpan, 0)
+	for start := 0; start < len(text); {
+		r, size := utf8.DecodeRuneInString(text[start:])
+		end := start + size
+		switch {
+		case r == '\'' || r == '’' || unicode.IsSpace(r):
+			// 分隔符
+		case c.isPinyinLetter(r):
+			for end < len(text) {
+				next, nextSize := utf8.DecodeRuneInString(text[end:])
+				if !c.isPinyinLetter(next) && combiningTone(next) == 0 {
+					break
+				}
+				end += nextSize
+			}
+			spans = append(spans, c.letterSpans(text, start, end)...)
+		case unicode.IsDigit(r):
+			for end < len(text) {
+				next, nextSize := utf8.DecodeRuneInString(text[end:])
+				if !unicode.IsDigit(next) {
+					break
+				}
+				end += nextSize
+			}
+			if last := len(spans) - 1; end-start == 1 && r >= '1' && r <= '5' && last >= 0 &&
+				spans[last].Kind == SpanPinyin && spans[last].End == start && !c.hasToneMark(spans[last].Text) {
+				spans[last].Text += string(r)
+				spans[last].End = end
+				break
+			}
+			spans = append(spans, PinyinSpan{Kind: SpanDigits, Text: text[start:end], Start: start, End: end})
+		default:
+			for end < len(text) {
+				next, nextSize := utf8.DecodeRuneInString(text[end:])
+				if next == '\'' || next == '’' || unicode.IsSpace(next) || c.isPinyinLetter(next) || unicode.IsDigit(next) {
+					break
+				}
+				end += nextSize
+			}
+			spans = append(spans, PinyinSpan{Kind: SpanUnknown, Text: text[start:end], Start: start, End: end})
+		}
+		start = end
+	}
+	return spans, nil
+}
+
+// isPinyinLetter 判断是否为拼音中可能出现的字母（拉丁字母、ü 和带调字母，不区分大小写）
+func (c *Chinese) isPinyinLetter(r rune) bool {
+	r = unicode.ToLower(r)
+	if r >= 'a' && r <= 'z' || r == 'ü' {
+		return true
+	}
+	sound, exists := c.soundData[r]
+	return exists && isPinyinBase(sound.ab)
+}
+
+// letterSpans 将 text[start:end] 中连续的字母切分为拼音音节和剩余字母
+func (c *Chinese) letterSpans(text string, start, end int) []PinyinSpan {
+	// 部分字母转小写后字节长度会变化（如 İ、K），记录小写文本中每个字符对应的原文位置
+	lower := make([]byte, 0, end-start)
+	origins := make([]int, 0, end-start+1)
+	for i, r := range text[start:end] {
+		lowered := utf8.AppendRune(lower, unicode.ToLower(r))
+		for range len(lowered) - len(lower) {
+			origins = append(origins, start+i)
+		}
+		lower = lowered
+	}
+	origins = append(origins, end)
+
+	chunks, ok := c.parsePinyinChunks(string(lower))
+	if !ok || len(chunks) != 1 {
+		return []PinyinSpan{{Kind: SpanLetters, Text: text[start:end], Start: start, End: end}}
+	}
+	chunk := chunks[0]
+
+	n := len(chunk.base)
+	next := c.letterSplit(chunk)
+
+	// 与剩余字母相邻的过短连续音节、夹在剩余字母中间的连续音节并入剩余字母
+	for i := 0; i < n; {
+		if next[i] == 0 {
+			i++
+			continue
+		}
+		groupEnd := i
+		for groupEnd < n && next[groupEnd] > 0 {
+			groupEnd += next[groupEnd]
+		}
+		embedded := i > 0 && groupEnd < n
+		if embedded || (i > 0 || groupEnd < n) && groupEnd-i < spanMinSyllableLetters {
+			clear(next[i:groupEnd])
+		}
+		i = groupEnd
+	}
+
+	// chunk.starts 为小写文本中的字节位置
+	offset := func(i int) int {
+		if i == n {
+			return end
+		}
+		return origins[chunk.starts[i]]
+	}
+	spans := make([]PinyinSpan, 0)
+	for i := 0; i < n; {
+		if length := next[i]; length > 0 {
+			spans = append(spans, PinyinSpan{Kind: SpanPinyin, Text: text[offset(i):offset(i+length)], Start: offset(i), End: offset(i + length)})
+			i += length
+			continue
+		}
+		if last := len(spans) - 1; last >= 0 && spans[last].Kind == SpanLetters {
+			spans[last].End = offset(i + 1)
+			spans[last].Text = text[spans[last].Start:spans[last].End]
+		} else {
+			spans = append(spans, PinyinSpan{Kind: SpanLetters, Text: text[offset(i):offset(i+1)], Start: offset(i), End: offset(i + 1)})
+		}
+		i++
+	}
+	return spans
+}
+
+// letterSplit 从后往前计算每个位置之后剩余字母最少的切分
+// 返回每个位置选择的音节长度，0 表示该字母不切分为拼音
+func (c *Chinese) letterSplit(chunk pinyinChunk) []int {
+	n := len(chunk.base)
+	best := make([]spanCost, n+1)
+	next := make([]int, n+1)
+	options := &SplitOptions{}
+	for i := n - 1; i >= 0; i-- {
+		best[i] = spanCost{uncovered: best[i+1].uncovered + 1, syllables: best[i+1].syllables, rank: best[i+1].rank}
+		for _, length := range c.syllableLengths(chunk, i, options) {
+			tail := best[i+length]
+			cost := spanCost{uncovered: tail.uncovered, syllables: tail.syllables + 1, rank: tail.rank + c.syllableRank(chunk.base[i:i+length])}
+			if cost.less(best[i]) {
+				best[i], next[i] = cost, length
+			}
+		}
+	}
+	return next
+}
+
+// SplitPinyinSpans 全局函数：混合内容拼音分词
+func SplitPinyinSpans(text string) ([]PinyinSpan, error) {
+	return defaultChinese.SplitPinyinSpans(text)
+}
@@ -0,0 +1,275 @@
+package zhkit
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultSplitLimit 拼音分词默认返回的切分方案数上限
+const DefaultSplitLimit = 10
+
+// SplitOptions 拼音分词选项
+type SplitOptions struct {
+	Limit        int       // 最多返回的切分方案数，<= 0 时为 DefaultSplitLimit；Exhaustive 为 true 时不限制
+	Exhaustive   bool      // 是否按音节从短到长枚举所有切分方案（不排序，长输入的方案数会指数增长）
+	Fuzzy        FuzzyRule // 同时按哪些模糊音规则切分（如 tin 按 in/ing 切分为音节），为 0 时不启用
+	Abbreviation bool      // 是否允许只输入声母的简拼（单个辅音字母或 zh、ch、sh），如 bjdx 切分为 b j d x
+}
+
+// splitPath 一种切分方案
+type splitPath struct {
+	syllables []string
+	cost      int // 各音节的字频排名之和
+}
+
+// pinyinChunk 以隔音符号、空白或数字声调分隔的一段拼音，段内再按音节切分
+type pinyinChunk struct {
+	text   string // 原文（小写，不含结尾的数字声调）
+	base   string // 无调拼音，ü 记为 v
+	starts []int  // base 中每个字母对应的原文起始字节位置
+	toned  []bool // base 中每个字母是否带声调符号
+	digit  string // 结尾的数字声调
+}
+
+// SplitPinyinWithOptions 拼音分词（使用选项）
+// 隔音符号（xi'an）和空白是音节边界；支持带调字母（xī'ān）、组合声调符号和音节后的数字声调（xi1an1），
+// 切分出的音节保留原文的声调写法，一个音节最多带一个声调；
+// 默认按可能性排序：音节数少的在前，音节数相同时按音节的常用程度（音节最常用字的字频排名之和）排序；
+// 无法切分时返回整个输入
+func (c *Chinese) SplitPinyinWithOptions(pinyin string, options *SplitOptions) ([][]string, error) {
+	if options == nil {
+		options = &SplitOptions{}
+	}
+
+	pinyin = strings.ToLower(strings.TrimSpace(pinyin))
+	if pinyin == "" {
+		return [][]string{}, nil
+	}
+
+	paths, ok := c.splitPinyinPaths(pinyin, options)
+	if !ok {
+		return [][]string{{pinyin}}, nil
+	}
+
+	results := make([][]string, len(paths))
+	for i, path := range paths {
+		results[i] = path.syllables
+	}
+	return results, nil
+}
+
+// splitPinyinPaths 按选项切分小写的拼音，无法切分时返回 false
+func (c *Chinese) splitPinyinPaths(pinyin string, options *SplitOptions) ([]splitPath, bool) {
+	limit := options.Limit
+	if limit <= 0 {
+		limit = DefaultSplitLimit
+	}
+
+	chunks, ok := c.parsePinyinChunks(pinyin)
+	if !ok {
+		return nil, false
+	}
+
+	// 各段独立切分，再依次组合
+	paths := []splitPath{{}}
+	for _, chunk := range chunks {
+		var chunkPaths []splitPath
+		if options.Exhaustive {
+			chunkPaths = c.splitChunkExhaustive(chunk, 0, options)
+		} else {
+			chunkPaths = c.splitChunkRanked(chunk, limit, options)
+		}
+		if len(chunkPaths) == 0 {
+			return nil, false
+		}
+
+		paths = joinSplitPaths(paths, chunkPaths)
+		if !options.Exhaustive {
+			slices.SortFunc(paths, compareSplitPath)
+			paths = paths[:min(limit, len(paths))]
+		}
+	}
+	return paths, true
+}
+
+// parsePinyinChunks 按隔音符号、空白和数字声调将拼音分段，并去掉声调
+// 含有无法识别的字符时返回 false
+func (c *Chinese) parsePinyinChunks(pinyin string) ([]pinyinChunk, bool) {
+	chunks := make([]pinyinChunk, 0)
+	current := pinyinChunk{}
+	chunkStart := 0
+	flush := func(end int) {
+		if current.base != "" {
+			current.text = pinyin[chunkStart:end]
+			chunks = append(chunks, current)
+		}
+		current = pinyinChunk{}
+	}
+
+	for i, r := range pinyin {
+		size := utf8.RuneLen(r)
+		switch {
+		case r == '\'' || r == '’' || unicode.IsSpace(r):
+			flush(i)
+			chunkStart = i + size
+		case r >= '1' && r <= '5':
+			if current.base == "" {
+				return nil, false
+			}
+			current.digit = string(r)
+			flush(i)
+			chunkStart = i + size
+		case combiningTone(r) > 0:
+			if current.base == "" {
+				return nil, false
+			}
+			current.toned[len(current.toned)-1] = true
+		default:
+			letters, toned := string(r), false
+			if sound, exists := c.soundData[r]; exists {
+				letters, toned = sound.ab, sound.tone > 0
+			} else if r == 'ü' {
+				letters = "v"
+			}
+			if !isPinyinBase(letters) {
+				return nil, false
+			}
+			for k := range len(letters) {
+				current.starts = append(current.starts, i-chunkStart)
+				current.toned = append(current.toned, toned && k == 0)
+			}
+			current.base += letters
+		}
+	}
+	flush(len(pinyin))
+
+	return chunks, len(chunks) > 0
+}
+
+// syllable 返回 base[start:end] 对应的原文，最后一个音节带上数字声调
+func (chunk pinyinChunk) syllable(start, end int) string {
+	if end == len(chunk.base) {
+		return chunk.text[chunk.starts[start]:] + chunk.digit
+	}
+	return chunk.text[chunk.starts[start]:chunk.starts[end]]
+}
+
+// syllableLengths 返回从 start 开始可以切分出的音节（及允许时的简拼）长度，从短到长，跳过带多个声调的音节
+// 自成音节的鼻音（m、n、ng、hm、hng）只在单独成段时切分（如 "ng5"、"hm"），避免 xian 切出 xia n
+func (c *Chinese) syllableLengths(chunk pinyinChunk, start int, options *SplitOptions) []int {
+	lengths := c.syllablePrefixes(chunk.base[start:], options.Fuzzy)
+	if options.Abbreviation {
+		for length := 1; length <= 2 && start+length <= len(chunk.base); length++ {
+			if isAbbreviation(chunk.base[start:start+length]) && !slices.Contains(lengths, length) {
+				lengths = append(lengths, length)
+			}
+		}
+		slices.Sort(lengths)
+	}
+
+	return slices.DeleteFunc(lengths, func(length int) bool {
+		if isEmbeddedNasal(chunk.base[start:start+length], start == 0 && start+length == len(chunk.base)) {
+			return !options.Abbreviation || !isAbbreviation(chunk.base[start:start+length])
+		}
+		tones := 0
+		for _, toned := range chunk.toned[start : start+length] {
+			if toned {
+				tones++
+			}
+		}
+		if start+length == len(chunk.base) && chunk.digit != "" {
+			tones++
+		}
+		return tones > 1
+	})
+}
+
+// isEmbeddedNasal 判断是否为不单独成段的自成音节鼻音，这类鼻音不作为音节切分
+func isEmbeddedNasal(syllable string, standalone bool) bool {
+	return !standalone && slices.Contains(syllabicNasals, syllable)
+}
+
+// splitChunkRanked 动态规划求一段拼音最优的 limit 种切分方案
+// 从后往前计算每个位置之后的最优方案：音节数和字频排名都可以相加，
+// 所以整体最优的 limit 种方案只会由各后缀最优的 limit 种方案组成
+func (c *Chinese) splitChunkRanked(chunk pinyinChunk, limit int, options *SplitOptions) []splitPath {
+	best := make([][]splitPath, len(chunk.base)+1)
+	best[len(chunk.base)] = []splitPath{{}}
+
+	for i := len(chunk.base) - 1; i >= 0; i-- {
+		candidates := make([]splitPath, 0)
+		for _, length := range c.syllableLengths(chunk, i, options) {
+			syllable := chunk.syllable(i, i+length)
+			rank := c.syllableRank(chunk.base[i : i+length])
+			for _, tail := range best[i+length] {
+				candidates = append(candidates, splitPath{
+					syllables: append([]string{syllable}, tail.syllables...),
+					cost:      rank + tail.cost,
+				})
+			}
+		}
+
+		slices.SortFunc(candidates, compareSplitPath)
+		best[i] = candidates[:min(limit, len(candidates))]
+	}
+
+	return best[0]
+}
+
+// splitChunkExhaustive 递归枚举一段拼音从 start 开始的所有切分方案
+func (c *Chinese) splitChunkExhaustive(chunk pinyinChunk, start int, options *SplitOptions) []splitPath {
+	if start == len(chunk.base) {
+		return []splitPath{{}}
+	}
+
+	paths := make([]splitPath, 0)
+	for _, length := range c.syllableLengths(chunk, start, options) {
+		syllable := chunk.syllable(start, start+length)
+		for _, tail := range c.splitChunkExhaustive(chunk, start+length, options) {
+			paths = append(paths, splitPath{syllables: append([]string{syllable}, tail.syllables...)})
+		}
+	}
+	return paths
+}
+
+// joinSplitPaths 将前面各段的切分方案与下一段的切分方案两两组合
+func joinSplitPaths(heads, tails []splitPath) []splitPath {
+	paths := make([]splitPath, 0, len(heads)*len(tails))
+	for _, head := range heads {
+		for _, tail := range tails {
+			paths = append(paths, splitPath{
+				syllables: append(slices.Clip(head.syllables), tail.syllables...),
+				cost:      head.cost + tail.cost,
+			})
+		}
+	}
+	return paths
+}
+
+// compareSplitPath 按音节数、字频排名之和、音节字母顺序比较切分方案
+func compareSplitPath(a, b splitPath) int {
+	if len(a.syllables) != len(b.syllables) {
+		return len(a.syllables) - len(b.syllables)
+	}
+	if a.cost != b.cost {
+		return a.cost - b.cost
+	}
+	return slices.Compare(a.syllables, b.syllables)
+}
+
+// syllableRank 返回音节最常用字的字频排名，没有字频数据（或只是模糊音写法）的音节按字频表长度计
+func (c *Chinese) syllableRank(syllable string) int {
+	if entries := c.hanziIndex()[umlautSyllable(syllable)]; len(entries) > 0 {
+		if rank, exists := c.charRank[entries[0].char]; exists {
+			return rank
+		}
+	}
+	return len(c.charRank) + 1
+}
+
+// SplitPinyinWithOptions 全局函数：拼音分词（使用选项）
+func SplitPinyinWithOptions(pinyin string, options *SplitOptions) ([][]string, error) {
+	return defaultChinese.SplitPinyinWithOptions(pinyin, options)
+}
@@ -0,0 +1,100 @@
+package zhkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// parseSurnameData 解析姓氏读音数据
+// 格式: {"单": "shàn", "欧阳": "ōu yáng"}，拼音之间用空格分隔
+func (c *Chinese) parseSurnameData(data map[string]string) error {
+	for surname, pinyin := range data {
+		if err := c.AddSurname(surname, strings.Fields(pinyin)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddSurname 添加姓氏读音，用于姓名转拼音
+// surname: 姓氏，支持复姓，如 "单"、"欧阳"
+// pinyins: 每个字的读音，如 "ōu", "yáng"，也支持数字声调写法 "ou1", "yang2"
+// 该方法会修改实例数据，应在并发使用前调用
+func (c *Chinese) AddSurname(surname string, pinyins ...string) error {
+	runes := []rune(surname)
+	if len(runes) == 0 {
+		return fmt.Errorf("姓氏不能为空")
+	}
+	if len(runes) != len(pinyins) {
+		return fmt.Errorf("姓氏 %s 的字数与拼音数量不一致", surname)
+	}
+
+	readings := make([]string, len(pinyins))
+	for i, py := range pinyins {
+		readings[i] = c.addToneMarks(py)
+	}
+
+	c.surnameData[surname] = readings
+	if len(runes) > c.maxSurnameLen {
+		c.maxSurnameLen = len(runes)
+	}
+	return nil
+}
+
+// LoadSurnameData 加载姓氏读音数据
+func (c *Chinese) LoadSurnameData(dataPath string) error {
+	filePath := filepath.Join(dataPath, "surnamesData.json")
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("无法打开姓氏数据文件: %v", err)
+	}
+	defer file.Close()
+
+	var data map[string]string
+	decoder := json.NewDecoder(file)
+	if err := decoder.Decode(&data); err != nil {
+		return fmt.Errorf("解析姓氏数据失败: %v", err)
+	}
+
+	return c.parseSurnameData(data)
+}
+
+// matchSurname 匹配姓名开头的姓氏，优先匹配复姓
+// 返回姓氏各字的读音，没有匹配时返回 nil
+func (c *Chinese) matchSurname(runes []rune) []string {
+	length := c.maxSurnameLen
+	if length > len(runes) {
+		length = len(runes)
+	}
+
+	for ; length >= 1; length-- {
+		if readings, exists := c.surnameData[string(runes[:length])]; exists {
+			return readings
+		}
+	}
+	return nil
+}
+
+// NameToPinyin 姓名转拼音
+// 开头的姓氏（包括复姓）使用姓氏读音，名字部分按普通文本转换
+func (c *Chinese) NameToPinyin(name string, options *PinyinOptions) (*PinyinResult, error) {
+	nameOptions := PinyinOptions{}
+	if options != nil {
+		nameOptions = *options
+	}
+	nameOptions.IsName = true
+	return c.ToPinyinWithOptions(name, &nameOptions)
+}
+
+// AddSurname 全局函数：添加姓氏读音
+func AddSurname(surname string, pinyins ...string) error {
+	return defaultChinese.AddSurname(surname, pinyins...)
+}
+
+// NameToPinyin 全局函数：姓名转拼音
+func NameToPinyin(name string, options *PinyinOptions) (*PinyinResult, error) {
+	return defaultChinese.NameToPinyin(name, options)
+}
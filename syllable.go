@@ -0,0 +1,175 @@
+package zhkit
+
+import (
+	"slices"
+	"strings"
+)
+
+// syllableNode 拼音音节前缀树的节点
+type syllableNode struct {
+	children map[byte]*syllableNode
+	syllable bool // 从根节点到此是否为完整音节
+}
+
+// newSyllableNode 创建空的音节前缀树节点
+func newSyllableNode() *syllableNode {
+	return &syllableNode{children: make(map[byte]*syllableNode)}
+}
+
+// insert 插入音节
+func (n *syllableNode) insert(syllable string) {
+	node := n
+	for i := 0; i < len(syllable); i++ {
+		child, exists := node.children[syllable[i]]
+		if !exists {
+			child = newSyllableNode()
+			node.children[syllable[i]] = child
+		}
+		node = child
+	}
+	node.syllable = true
+}
+
+// contains 判断是否为完整音节
+func (n *syllableNode) contains(syllable string) bool {
+	node := n
+	for i := 0; i < len(syllable) && node != nil; i++ {
+		node = node.children[syllable[i]]
+	}
+	return node != nil && node.syllable
+}
+
+// prefixes 返回 text 开头所有完整音节的长度，从短到长
+func (n *syllableNode) prefixes(text string) []int {
+	lengths := make([]int, 0, 2)
+	node := n
+	for i := 0; i < len(text); i++ {
+		if node = node.children[text[i]]; node == nil {
+			break
+		}
+		if node.syllable {
+			lengths = append(lengths, i+1)
+		}
+	}
+	return lengths
+}
+
+// syllables 返回所有音节，按字母排序
+func (n *syllableNode) syllables() []string {
+	results := make([]string, 0)
+	var walk func(node *syllableNode, prefix []byte)
+	walk = func(node *syllableNode, prefix []byte) {
+		if node.syllable {
+			results = append(results, string(prefix))
+		}
+		for char, child := range node.children {
+			walk(child, append(prefix, char))
+		}
+	}
+	walk(n, nil)
+	slices.Sort(results)
+	return results
+}
+
+// defaultSyllables 内置音节表（ü 记为 v），加载 split 数据之前使用，也用于过滤 split 数据中的非拼音拼写
+// 包括 dei（得）、shei（谁）、fiao（覅）等少见音节
+var defaultSyllables = []string{
+	"a", "ai", "an", "ang", "ao",
+	"ba", "bai", "ban", "bang", "bao", "bei", "ben", "beng", "bi", "bian", "biao", "bie", "bin", "bing", "bo", "bu",
+	"ca", "cai", "can", "cang", "cao", "ce", "cen", "ceng", "cha", "chai", "chan", "chang", "chao", "che", "chen", "cheng", "chi", "chong", "chou", "chu", "chua", "chuai", "chuan", "chuang", "chui", "chun", "chuo", "ci", "cong", "cou", "cu", "cuan", "cui", "cun", "cuo",
+	"da", "dai", "dan", "dang", "dao", "de", "dei", "den", "deng", "di", "dia", "dian", "diao", "die", "ding", "diu", "dong", "dou", "du", "duan", "dui", "dun", "duo",
+	"e", "ei", "en", "eng", "er",
+	"fa", "fan", "fang", "fei", "fen", "feng", "fiao", "fo", "fou", "fu",
+	"ga", "gai", "gan", "gang", "gao", "ge", "gei", "gen", "geng", "gong", "gou", "gu", "gua", "guai", "guan", "guang", "gui", "gun", "guo",
+	"ha", "hai", "han", "hang", "hao", "he", "hei", "hen", "heng", "hong", "hou", "hu", "hua", "huai", "huan", "huang", "hui", "hun", "huo",
+	"ji", "jia", "jian", "jiang", "jiao", "jie", "jin", "jing", "jiong", "jiu", "ju", "juan", "jue", "jun",
+	"ka", "kai", "kan", "kang", "kao", "ke", "kei", "ken", "keng", "kong", "kou", "ku", "kua", "kuai", "kuan", "kuang", "kui", "kun", "kuo",
+	"la", "lai", "lan", "lang", "lao", "le", "lei", "leng", "li", "lia", "lian", "liang", "liao", "lie", "lin", "ling", "liu", "long", "lou", "lu", "luan", "lue", "lun", "luo", "lv",
+	"ma", "mai", "man", "mang", "mao", "me", "mei", "men", "meng", "mi", "mian", "miao", "mie", "min", "ming", "miu", "mo", "mou", "mu",
+	"na", "nai", "nan", "nang", "nao", "ne", "nei", "nen", "neng", "ni", "nian", "niang", "niao", "nie", "nin", "ning", "niu", "nong", "nou", "nu", "nuan", "nue", "nun", "nuo", "nv",
+	"o", "ou",
+	"pa", "pai", "pan", "pang", "pao", "pei", "pen", "peng", "pi", "pian", "piao", "pie", "pin", "ping", "po", "pou", "pu",
+	"qi", "qia", "qian", "qiang", "qiao", "qie", "qin", "qing", "qiong", "qiu", "qu", "quan", "que", "qun",
+	"ran", "rang", "rao", "re", "ren", "reng", "ri", "rong", "rou", "ru", "ruan", "rui", "run", "ruo",
+	"sa", "sai", "san", "sang", "sao", "se", "sei", "sen", "seng", "sha", "shai", "shan", "shang", "shao", "she", "shei", "shen", "sheng", "shi", "shou", "shu", "shua", "shuai", "shuan", "shuang", "shui", "shun", "shuo", "si", "song", "sou", "su", "suan", "sui", "sun", "suo",
+	"ta", "tai", "tan", "tang", "tao", "te", "teng", "ti", "tian", "tiao", "tie", "ting", "tong", "tou", "tu", "tuan", "tui", "tun", "tuo",
+	"wa", "wai", "wan", "wang", "wei", "wen", "weng", "wo", "wu",
+	"xi", "xia", "xian", "xiang", "xiao", "xie", "xin", "xing", "xiong", "xiu", "xu", "xuan", "xue", "xun",
+	"ya", "yan", "yang", "yao", "ye", "yi", "yin", "ying", "yo", "yong", "you", "yu", "yuan", "yue", "yun",
+	"za", "zai", "zan", "zang", "zao", "ze", "zei", "zen", "zeng", "zha", "zhai", "zhan", "zhang", "zhao", "zhe", "zhen", "zheng", "zhi", "zhong", "zhou", "zhu", "zhua", "zhuai", "zhuan", "zhuang", "zhui", "zhun", "zhuo", "zi", "zong", "zou", "zu", "zuan", "zui", "zun", "zuo",
+}
+
+// syllabicNasals 自成音节的鼻音（嗯 ń、呣 ḿ、噷 hm、哼 hng），无法拆分为声母和韵母，单独加入音节表
+var syllabicNasals = []string{"m", "n", "ng", "hm", "hng"}
+
+// newDefaultSyllableTrie 使用内置音节表创建音节前缀树
+func newDefaultSyllableTrie() *syllableNode {
+	trie := newSyllableNode()
+	for _, syllable := range defaultSyllables {
+		trie.insert(syllable)
+		trie.insert(umlautSyllable(syllable))
+	}
+	for _, syllable := range syllabicNasals {
+		trie.insert(syllable)
+	}
+	return trie
+}
+
+// parsePinyinSplitData 解析 pinyinData.json 中 split 表的 relation 前缀树
+// 格式: {"z": {"h": {"i": {"py": true}}}}，"py" 为 true 表示到此为完整音节
+// 数据中混有非汉语拼音的拼写（如 phdeng、gi、len），不在内置音节表中的音节会被忽略；
+// 数据中的 lue、nue 同时记为 lve、nve（ü 记为 v）；自成音节的鼻音总是加入音节表
+func (c *Chinese) parsePinyinSplitData(relation map[string]interface{}) error {
+	trie := newSyllableNode()
+	var walk func(node map[string]interface{}, prefix string)
+	walk = func(node map[string]interface{}, prefix string) {
+		for key, value := range node {
+			if key == "py" {
+				if isSyllable, _ := value.(bool); isSyllable && isDefaultSyllable(prefix) {
+					trie.insert(prefix)
+					trie.insert(umlautSyllable(prefix))
+				}
+				continue
+			}
+			if child, ok := value.(map[string]interface{}); ok {
+				walk(child, prefix+strings.ToLower(key))
+			}
+		}
+	}
+	walk(relation, "")
+	for _, syllable := range syllabicNasals {
+		trie.insert(syllable)
+	}
+
+	c.syllableTrie = trie
+	return nil
+}
+
+// isDefaultSyllable 判断无调拼音是否在内置音节表中（lve、nve 按 lue、nue 处理）
+func isDefaultSyllable(syllable string) bool {
+	return slices.ContainsFunc(defaultSyllables, func(s string) bool {
+		return s == syllable || umlautSyllable(s) == syllable
+	})
+}
+
+// isValidPinyin 检查是否为音节表中的音节（不带声调，ü 记为 v）
+func (c *Chinese) isValidPinyin(pinyin string) bool {
+	return c.syllableTrie.contains(pinyin)
+}
+
+// syllablePrefixes 返回 pinyin 开头可以切分出的音节长度（含规则下的模糊音写法），从短到长
+func (c *Chinese) syllablePrefixes(pinyin string, fuzzy FuzzyRule) []int {
+	lengths := c.syllableTrie.prefixes(pinyin)
+	if fuzzy == 0 {
+		return lengths
+	}
+
+	// 模糊音写法不在音节表中，逐个检查不超过 6 个字母的前缀
+	for i := 1; i <= len(pinyin) && i <= 6; i++ {
+		if !slices.Contains(lengths, i) && c.isFuzzyPinyin(pinyin[:i], fuzzy) {
+			lengths = append(lengths, i)
+		}
+	}
+	slices.Sort(lengths)
+	return lengths
+}
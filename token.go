@@ -0,0 +1,60 @@
+package zhkit
+
+import "unicode/utf8"
+
+// PinyinToken 拼音转换单元及其在原文中的位置
+type PinyinToken struct {
+	Text      string   `json:"text"`      // 原文
+	Start     int      `json:"start"`     // 起始字节偏移
+	End       int      `json:"end"`       // 结束字节偏移（不含）
+	RuneStart int      `json:"runeStart"` // 起始字符（rune）偏移
+	RuneEnd   int      `json:"runeEnd"`   // 结束字符（rune）偏移（不含）
+	IsHan     bool     `json:"isHan"`     // 是否为汉字
+	Readings  []string `json:"readings"`  // 按 options.Mode 转换的全部读音；非中文及没有拼音数据的汉字为原文（或替换字符串）
+}
+
+// ToPinyinTokens 汉字转拼音（返回带原文位置的转换单元）
+// options.Mode 只能指定一种转换模式，组合多种模式（如 ModePinyin|ModePinyinFirst）会返回错误；
+// 需要多种写法时按模式分别调用，相同文本和选项切分出的单元及位置一致；被 CharRemove 删除的字符不会生成单元
+func (c *Chinese) ToPinyinTokens(text string, options *PinyinOptions) ([]PinyinToken, error) {
+	options = c.normalizePinyinOptions(options)
+	converter, err := findPinyinConverter(options.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	// 字符偏移到字节偏移的映射
+	byteOffsets := make([]int, 0, utf8.RuneCountInString(text)+1)
+	for i := range text {
+		byteOffsets = append(byteOffsets, i)
+	}
+	byteOffsets = append(byteOffsets, len(text))
+
+	units := c.pinyinUnits(text, options)
+	tokens := make([]PinyinToken, 0, len(units))
+	for _, unit := range units {
+		token := PinyinToken{
+			Text:      text[byteOffsets[unit.start]:byteOffsets[unit.end]],
+			Start:     byteOffsets[unit.start],
+			End:       byteOffsets[unit.end],
+			RuneStart: unit.start,
+			RuneEnd:   unit.end,
+			IsHan:     unit.isHan,
+		}
+
+		if unit.pinyins == nil {
+			token.Readings = []string{unit.text}
+		} else {
+			token.Readings = c.convertReadings(unit.pinyins, converter, options)
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+// ToPinyinTokens 全局函数：汉字转拼音（返回带原文位置的转换单元）
+func ToPinyinTokens(text string, options *PinyinOptions) ([]PinyinToken, error) {
+	return defaultChinese.ToPinyinTokens(text, options)
+}
@@ -0,0 +1,193 @@
+package zhkit
+
+import (
+	"strconv"
+	"strings"
+)
+
+// soundInfo 带调字母信息（对应 pinyinData.json 中的 sound 表）
+type soundInfo struct {
+	ab   string // 去掉声调后的字母，ü 记为 v
+	tone int    // 声调，0 表示不带声调
+}
+
+// toneCombiningMarks 组合声调符号，下标即声调
+// 用于解析 "m̀" 这类使用组合字符的读音，以及 sound 表中没有对应预组合字符时的输出
+var toneCombiningMarks = []rune{0, '\u0304', '\u0301', '\u030c', '\u0300'}
+
+// neutralTone 轻声的数字声调
+const neutralTone = 5
+
+// defaultSoundData 内置带调字母表，加载 sound 数据之前使用（与 pinyinData.json 中的 sound 表一致）
+var defaultSoundData = map[rune]soundInfo{
+	'a': {"a", 0}, 'ā': {"a", 1}, 'á': {"a", 2}, 'ǎ': {"a", 3}, 'à': {"a", 4},
+	'o': {"o", 0}, 'ō': {"o", 1}, 'ó': {"o", 2}, 'ǒ': {"o", 3}, 'ò': {"o", 4},
+	'e': {"e", 0}, 'ē': {"e", 1}, 'é': {"e", 2}, 'ě': {"e", 3}, 'è': {"e", 4},
+	'ī': {"i", 1}, 'í': {"i", 2}, 'ǐ': {"i", 3}, 'ì': {"i", 4},
+	'ū': {"u", 1}, 'ú': {"u", 2}, 'ǔ': {"u", 3}, 'ù': {"u", 4},
+	'ü': {"v", 0}, 'ǖ': {"v", 1}, 'ǘ': {"v", 2}, 'ǚ': {"v", 3}, 'ǜ': {"v", 4},
+	'ń': {"n", 2}, 'ň': {"n", 3}, 'ǹ': {"n", 4},
+	'ḿ': {"m", 2},
+}
+
+// newDefaultSoundData 根据内置带调字母表创建 soundData 和 toneMarkData
+func newDefaultSoundData() (map[rune]soundInfo, map[soundInfo]rune) {
+	soundData := make(map[rune]soundInfo, len(defaultSoundData))
+	toneMarkData := make(map[soundInfo]rune, len(defaultSoundData))
+	for char, sound := range defaultSoundData {
+		soundData[char] = sound
+		toneMarkData[sound] = char
+	}
+	return soundData, toneMarkData
+}
+
+// parseSoundData 解析 sound 表
+// 格式: {"ā": {"ab": "a", "tone": 1}}
+func (c *Chinese) parseSoundData(data map[string]interface{}) error {
+	for char, value := range data {
+		runes := []rune(char)
+		if len(runes) != 1 {
+			continue
+		}
+
+		info, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ab, ok := info["ab"].(string)
+		if !ok || ab == "" {
+			continue
+		}
+		tone, _ := info["tone"].(float64)
+
+		sound := soundInfo{ab: ab, tone: int(tone)}
+		c.soundData[runes[0]] = sound
+		c.toneMarkData[sound] = runes[0]
+	}
+	return nil
+}
+
+// splitTone 将拼音拆分为无调拼音和声调
+// 返回的无调拼音中 ü 记为 v，轻声返回 5
+// 支持带调字母、组合声调符号以及末尾的数字声调
+func (c *Chinese) splitTone(pinyin string) (string, int) {
+	var base strings.Builder
+	tone := 0
+
+	runes := []rune(pinyin)
+	for i, r := range runes {
+		if sound, exists := c.soundData[r]; exists {
+			base.WriteString(sound.ab)
+			if sound.tone > 0 {
+				tone = sound.tone
+			}
+			continue
+		}
+
+		if t := combiningTone(r); t > 0 {
+			tone = t
+			continue
+		}
+
+		if i == len(runes)-1 && r >= '1' && r <= '5' && i > 0 {
+			tone = int(r - '0')
+			continue
+		}
+
+		base.WriteRune(r)
+	}
+
+	if tone == 0 {
+		tone = neutralTone
+	}
+	return base.String(), tone
+}
+
+// combiningTone 返回组合声调符号对应的声调，不是声调符号时返回 0
+func combiningTone(r rune) int {
+	for tone := 1; tone < len(toneCombiningMarks); tone++ {
+		if toneCombiningMarks[tone] == r {
+			return tone
+		}
+	}
+	return 0
+}
+
+// toneMarkIndex 返回标调字母在无调拼音中的位置
+// 规则：有 a 标 a；没有 a 标 e；ou 标 o；否则标最后一个元音；没有元音时标 m/n（如 ng、hm）
+func toneMarkIndex(base string) int {
+	if i := strings.IndexByte(base, 'a'); i >= 0 {
+		return i
+	}
+	if i := strings.IndexByte(base, 'e'); i >= 0 {
+		return i
+	}
+	if i := strings.Index(base, "ou"); i >= 0 {
+		return i
+	}
+	if i := strings.LastIndexAny(base, "iouv"); i >= 0 {
+		return i
+	}
+	return strings.IndexAny(base, "nm")
+}
+
+// markTone 按标调规则为无调拼音加上声调符号，ü 以 v 或 ü 输入均可
+func (c *Chinese) markTone(base string, tone int) string {
+	base = strings.ReplaceAll(base, "ü", "v")
+	index := toneMarkIndex(base)
+
+	var result strings.Builder
+	for i, r := range base {
+		if i != index || tone < 1 || tone > 4 {
+			if r == 'v' {
+				result.WriteRune(c.toneMarkRune("v", 0))
+			} else {
+				result.WriteRune(r)
+			}
+			continue
+		}
+
+		if marked, exists := c.toneMarkData[soundInfo{ab: string(r), tone: tone}]; exists {
+			result.WriteRune(marked)
+		} else {
+			result.WriteRune(c.toneMarkRune(string(r), 0))
+			result.WriteRune(toneCombiningMarks[tone])
+		}
+	}
+	return result.String()
+}
+
+// toneMarkRune 返回字母在指定声调下的写法，sound 表中没有时返回字母本身
+func (c *Chinese) toneMarkRune(ab string, tone int) rune {
+	if marked, exists := c.toneMarkData[soundInfo{ab: ab, tone: tone}]; exists {
+		return marked
+	}
+	if ab == "v" {
+		return 'ü'
+	}
+	return []rune(ab)[0]
+}
+
+// addToneMarks 添加声调符号
+func (c *Chinese) addToneMarks(pinyin string) string {
+	base, tone := c.splitTone(pinyin)
+	return c.markTone(base, tone)
+}
+
+// addToneNumbers 添加数字声调，ü 记为 v，轻声记为 5
+func (c *Chinese) addToneNumbers(pinyin string) string {
+	base, tone := c.splitTone(pinyin)
+	return base + strconv.Itoa(tone)
+}
+
+// removeTone 去掉声调，ü 保留原写法
+func (c *Chinese) removeTone(pinyin string) string {
+	base, _ := c.splitTone(pinyin)
+	return strings.ReplaceAll(base, "v", "ü")
+}
+
+// toASCIIPinyin 去掉声调并将 ü 替换为指定写法
+func (c *Chinese) toASCIIPinyin(pinyin string, umlautReplacement string) string {
+	base, _ := c.splitTone(pinyin)
+	return strings.ReplaceAll(base, "v", umlautReplacement)
+}
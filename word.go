@@ -0,0 +1,67 @@
+package zhkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// parseWordData 解析输入法词库
+// 格式: {"北京": "běi jīng"}，拼音之间用空格分隔
+func (c *Chinese) parseWordData(data map[string]string) error {
+	for word, pinyin := range data {
+		if err := c.AddWord(word, strings.Fields(pinyin)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddWord 添加输入法词库中的词，用于 Candidates 组词
+// 与 AddPhrase 不同，输入法词库不参与汉字转拼音的多音字消歧
+// word: 词，如 "北京"
+// pinyins: 每个字的读音，如 "běi", "jīng"，也支持数字声调写法 "bei3", "jing1"
+// 该方法会修改实例数据，应在并发使用前调用
+func (c *Chinese) AddWord(word string, pinyins ...string) error {
+	runes := []rune(word)
+	if len(runes) < 2 {
+		return fmt.Errorf("词至少需要两个字: %s", word)
+	}
+	if len(runes) != len(pinyins) {
+		return fmt.Errorf("词 %s 的字数与拼音数量不一致", word)
+	}
+
+	readings := make([]string, len(pinyins))
+	for i, py := range pinyins {
+		readings[i] = c.addToneMarks(py)
+	}
+
+	c.wordData[word] = readings
+	c.resetIndexes()
+	return nil
+}
+
+// LoadWordData 加载输入法词库
+func (c *Chinese) LoadWordData(dataPath string) error {
+	filePath := filepath.Join(dataPath, "wordsData.json")
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("无法打开输入法词库文件: %v", err)
+	}
+	defer file.Close()
+
+	var data map[string]string
+	decoder := json.NewDecoder(file)
+	if err := decoder.Decode(&data); err != nil {
+		return fmt.Errorf("解析输入法词库失败: %v", err)
+	}
+
+	return c.parseWordData(data)
+}
+
+// AddWord 全局函数：添加输入法词库中的词
+func AddWord(word string, pinyins ...string) error {
+	return defaultChinese.AddWord(word, pinyins...)
+}
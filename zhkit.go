@@ -4,13 +4,15 @@ package zhkit
 
 import (
 	"strings"
+	"sync"
+	"unicode"
 )
 
 // ConvertMode 转换模式
 type ConvertMode int
 
 const (
-	// ModePinyin 全拼模式
+	// ModePinyin 全拼模式（不带声调，保留 ü）
 	ModePinyin ConvertMode = 1 << iota
 	// ModePinyinFirst 首字母模式
 	ModePinyinFirst
@@ -18,6 +20,18 @@ const (
 	ModePinyinSound
 	// ModePinyinSoundNumber 读音数字模式（数字声调）
 	ModePinyinSoundNumber
+	// ModePinyinASCII 纯 ASCII 全拼模式（不带声调，ü 默认写作 v）
+	ModePinyinASCII
+	// ModePinyinInitial 声母模式（zh、ch、sh 等完整声母，零声母音节为空字符串）
+	ModePinyinInitial
+	// ModeZhuyin 注音符号模式（ㄓㄨㄥ、ㄍㄨㄛˊ，轻声符号 ˙ 在前）
+	ModeZhuyin
+	// ModeWadeGiles 威妥玛拼音模式（ch'ing2，声调写法见 PinyinOptions.ToneStyle）
+	ModeWadeGiles
+	// ModeYale 耶鲁拼音模式（ching1，声调写法见 PinyinOptions.ToneStyle）
+	ModeYale
+	// ModeIPA 国际音标模式（ʈʂʊŋ55，声调写法见 PinyinOptions.ToneStyle）
+	ModeIPA
 )
 
 // PinyinResult 拼音转换结果
@@ -26,6 +40,19 @@ type PinyinResult struct {
 	PinyinFirst       [][]string `json:"pinyinFirst,omitempty"`
 	PinyinSound       [][]string `json:"pinyinSound,omitempty"`
 	PinyinSoundNumber [][]string `json:"pinyinSoundNumber,omitempty"`
+	PinyinASCII       [][]string `json:"pinyinASCII,omitempty"`
+	PinyinInitial     [][]string `json:"pinyinInitial,omitempty"`
+	Zhuyin            [][]string `json:"zhuyin,omitempty"`
+	WadeGiles         [][]string `json:"wadeGiles,omitempty"`
+	Yale              [][]string `json:"yale,omitempty"`
+	IPA               [][]string `json:"ipa,omitempty"`
+}
+
+// pinyinInitials 声母表，双字母声母排在前面以便优先匹配
+var pinyinInitials = []string{
+	"zh", "ch", "sh",
+	"b", "p", "m", "f", "d", "t", "n", "l", "g", "k", "h",
+	"j", "q", "x", "r", "z", "c", "s",
 }
 
 // NumberOptions 数字转换选项
@@ -38,113 +65,92 @@ type Chinese struct {
 	pinyinData      map[rune][]string
 	simplifiedData  map[rune][]rune
 	traditionalData map[rune][]rune
-	pinyinSplitData map[string][]string
+	syllableTrie    *syllableNode
+	soundData       map[rune]soundInfo
+	toneMarkData    map[soundInfo]rune
+	umlautReplace   string
+	phraseData      map[string][]string
+	maxPhraseLen    int
+	wordData        map[string][]string // 输入法词库，只用于组词候选
+	surnameData     map[string][]string
+	maxSurnameLen   int
+	charWeight      map[rune]int // 常用程度，来自 charsData.json 的数字列
+	charRank        map[rune]int // 字频排名，1 为最常用
+	hanziIndexData  map[string][]hanziEntry
+	lexiconData     *lexicon
+	indexOnce       *sync.Once // 反查索引和组词索引按需构建，数据变化时重置
 }
 
 // NewChinese 创建新的中文工具实例
 func NewChinese() *Chinese {
+	soundData, toneMarkData := newDefaultSoundData()
 	c := &Chinese{
 		pinyinData:      make(map[rune][]string),
 		simplifiedData:  make(map[rune][]rune),
 		traditionalData: make(map[rune][]rune),
-		pinyinSplitData: make(map[string][]string),
+		syllableTrie:    newDefaultSyllableTrie(),
+		soundData:       soundData,
+		toneMarkData:    toneMarkData,
+		umlautReplace:   "v",
+		phraseData:      make(map[string][]string),
+		wordData:        make(map[string][]string),
+		surnameData:     make(map[string][]string),
+		charWeight:      make(map[rune]int),
+		charRank:        make(map[rune]int),
+		indexOnce:       &sync.Once{},
 	}
 	return c
 }
 
+// SetUmlautReplacement 设置 ModePinyinASCII 模式下 ü 的写法，默认为 "v"
+// 与 PinyinOptions.UmlautReplacement 相同，空字符串表示使用默认写法，不能用于去掉 ü
+func (c *Chinese) SetUmlautReplacement(replacement string) {
+	if replacement == "" {
+		replacement = "v"
+	}
+	c.umlautReplace = replacement
+}
+
 // ToPinyin 汉字转拼音
 // text: 要转换的文本
 // mode: 转换模式，可以使用位运算组合多种模式
-// separator: 分隔符，默认为空格
-// splitNonChinese: 是否分割非中文字符
+// separator: 分隔符，默认为空格（数组结果不使用，见 ToPinyinString）
+// splitNonChinese: 是否分割非中文字符，为 false 时连续的字母和数字作为一个整体
+// 更多选项请使用 ToPinyinWithOptions
 func (c *Chinese) ToPinyin(text string, mode ConvertMode, separator string, splitNonChinese bool) (*PinyinResult, error) {
-	if text == "" {
-		return &PinyinResult{}, nil
-	}
-
-	if separator == "" {
-		separator = " "
-	}
-
-	runes := []rune(text)
-	result := &PinyinResult{}
-
-	// 根据模式初始化结果数组
-	if mode&ModePinyin != 0 {
-		result.Pinyin = make([][]string, 0)
-	}
-	if mode&ModePinyinFirst != 0 {
-		result.PinyinFirst = make([][]string, 0)
-	}
-	if mode&ModePinyinSound != 0 {
-		result.PinyinSound = make([][]string, 0)
-	}
-	if mode&ModePinyinSoundNumber != 0 {
-		result.PinyinSoundNumber = make([][]string, 0)
-	}
+	return c.ToPinyinWithOptions(text, &PinyinOptions{
+		Mode:            mode,
+		Separator:       separator,
+		SplitNonChinese: splitNonChinese,
+	})
+}
 
-	// 处理每个字符
-	for _, r := range runes {
-		if pinyins, exists := c.pinyinData[r]; exists {
-			// 中文字符
-			if mode&ModePinyin != 0 {
-				result.Pinyin = append(result.Pinyin, pinyins)
-			}
-			if mode&ModePinyinFirst != 0 {
-				firsts := make([]string, len(pinyins))
-				for i, py := range pinyins {
-					if len(py) > 0 {
-						firsts[i] = string(py[0])
-					}
-				}
-				result.PinyinFirst = append(result.PinyinFirst, firsts)
-			}
-			if mode&ModePinyinSound != 0 {
-				sounds := make([]string, len(pinyins))
-				for i, py := range pinyins {
-					sounds[i] = c.addToneMarks(py)
-				}
-				result.PinyinSound = append(result.PinyinSound, sounds)
-			}
-			if mode&ModePinyinSoundNumber != 0 {
-				numbers := make([]string, len(pinyins))
-				for i, py := range pinyins {
-					numbers[i] = c.addToneNumbers(py)
-				}
-				result.PinyinSoundNumber = append(result.PinyinSoundNumber, numbers)
-			}
-		} else {
-			// 非中文字符
-			charStr := string(r)
-			if mode&ModePinyin != 0 {
-				result.Pinyin = append(result.Pinyin, []string{charStr})
-			}
-			if mode&ModePinyinFirst != 0 {
-				result.PinyinFirst = append(result.PinyinFirst, []string{charStr})
-			}
-			if mode&ModePinyinSound != 0 {
-				result.PinyinSound = append(result.PinyinSound, []string{charStr})
-			}
-			if mode&ModePinyinSoundNumber != 0 {
-				result.PinyinSoundNumber = append(result.PinyinSoundNumber, []string{charStr})
-			}
-		}
-	}
+// ToPinyinString 汉字转拼音（返回字符串）
+// 按分隔符拼接每个字的拼音，多音字取第一个读音，空白字符不输出
+// mode: 只能指定一种转换模式
+func (c *Chinese) ToPinyinString(text string, mode ConvertMode, separator string, splitNonChinese bool) (string, error) {
+	return c.ToPinyinStringWithOptions(text, &PinyinOptions{
+		Mode:            mode,
+		Separator:       separator,
+		SplitNonChinese: splitNonChinese,
+	})
+}
 
-	return result, nil
+// isAlphanumeric 是否为拉丁字母或数字
+func isAlphanumeric(r rune) bool {
+	return unicode.Is(unicode.Latin, r) || unicode.IsDigit(r)
 }
 
 // SplitPinyin 拼音分词（返回字符串）
+// 按可能性排序，最多返回 DefaultSplitLimit 种切分方案；需要全部方案时使用 SplitPinyinWithOptions 并设置 SplitOptions.Exhaustive
 func (c *Chinese) SplitPinyin(pinyin string) ([]string, error) {
 	if pinyin == "" {
 		return []string{}, nil
 	}
 
-	pinyin = strings.ToLower(strings.TrimSpace(pinyin))
-	results := c.splitPinyinRecursive(pinyin, []string{})
-
-	if len(results) == 0 {
-		return []string{pinyin}, nil
+	results, err := c.SplitPinyinWithOptions(pinyin, nil)
+	if err != nil {
+		return nil, err
 	}
 
 	// 转换为字符串格式
@@ -157,19 +163,9 @@ func (c *Chinese) SplitPinyin(pinyin string) ([]string, error) {
 }
 
 // SplitPinyinArray 拼音分词（返回数组）
+// 按可能性排序，最多返回 DefaultSplitLimit 种切分方案；需要全部方案时使用 SplitPinyinWithOptions 并设置 SplitOptions.Exhaustive
 func (c *Chinese) SplitPinyinArray(pinyin string) ([][]string, error) {
-	if pinyin == "" {
-		return [][]string{}, nil
-	}
-
-	pinyin = strings.ToLower(strings.TrimSpace(pinyin))
-	results := c.splitPinyinRecursive(pinyin, []string{})
-
-	if len(results) == 0 {
-		return [][]string{{pinyin}}, nil
-	}
-
-	return results, nil
+	return c.SplitPinyinWithOptions(pinyin, nil)
 }
 
 // ToSimplified 繁体转简体
@@ -212,74 +208,26 @@ func (c *Chinese) ToTraditional(text string) ([]string, error) {
 	return []string{string(result)}, nil
 }
 
-// addToneMarks 添加声调符号
-func (c *Chinese) addToneMarks(pinyin string) string {
-	// 简化实现，实际需要根据声调规则添加声调符号
-	return pinyin
-}
-
-// addToneNumbers 添加数字声调
-func (c *Chinese) addToneNumbers(pinyin string) string {
-	// 简化实现，实际需要根据声调规则添加数字声调
-	return pinyin + "1"
-}
-
-// splitPinyinRecursive 递归分词
-func (c *Chinese) splitPinyinRecursive(pinyin string, current []string) [][]string {
-	if pinyin == "" {
-		return [][]string{current}
-	}
-
-	var results [][]string
-
-	// 尝试不同长度的拼音
-	for i := 1; i <= len(pinyin) && i <= 6; i++ {
-		prefix := pinyin[:i]
-		if c.isValidPinyin(prefix) {
-			newCurrent := append(current, prefix)
-			subResults := c.splitPinyinRecursive(pinyin[i:], newCurrent)
-			results = append(results, subResults...)
-		}
+// firstLetter 返回拼音的首字母（ASCII），带调元音按去调后的字母处理
+func (c *Chinese) firstLetter(pinyin string) string {
+	base, _ := c.splitTone(pinyin)
+	for _, r := range base {
+		return strings.ToLower(string(r))
 	}
-
-	return results
+	return ""
 }
 
-// isValidPinyin 检查是否为有效拼音
-func (c *Chinese) isValidPinyin(pinyin string) bool {
-	// 简化实现，实际需要检查拼音字典
-	validPinyins := []string{
-		"a", "ai", "an", "ang", "ao",
-		"ba", "bai", "ban", "bang", "bao", "bei", "ben", "beng", "bi", "bian", "biao", "bie", "bin", "bing", "bo", "bu",
-		"ca", "cai", "can", "cang", "cao", "ce", "cen", "ceng", "cha", "chai", "chan", "chang", "chao", "che", "chen", "cheng", "chi", "chong", "chou", "chu", "chuai", "chuan", "chuang", "chui", "chun", "chuo", "ci", "cong", "cou", "cu", "cuan", "cui", "cun", "cuo",
-		"da", "dai", "dan", "dang", "dao", "de", "deng", "di", "dian", "diao", "die", "ding", "diu", "dong", "dou", "du", "duan", "dui", "dun", "duo",
-		"e", "en", "er",
-		"fa", "fan", "fang", "fei", "fen", "feng", "fo", "fou", "fu",
-		"ga", "gai", "gan", "gang", "gao", "ge", "gei", "gen", "geng", "gong", "gou", "gu", "gua", "guai", "guan", "guang", "gui", "gun", "guo",
-		"ha", "hai", "han", "hang", "hao", "he", "hei", "hen", "heng", "hong", "hou", "hu", "hua", "huai", "huan", "huang", "hui", "hun", "huo",
-		"ji", "jia", "jian", "jiang", "jiao", "jie", "jin", "jing", "jiong", "jiu", "ju", "juan", "jue", "jun",
-		"ka", "kai", "kan", "kang", "kao", "ke", "ken", "keng", "kong", "kou", "ku", "kua", "kuai", "kuan", "kuang", "kui", "kun", "kuo",
-		"la", "lai", "lan", "lang", "lao", "le", "lei", "leng", "li", "lia", "lian", "liang", "liao", "lie", "lin", "ling", "liu", "long", "lou", "lu", "luan", "lue", "lun", "luo", "lv",
-		"ma", "mai", "man", "mang", "mao", "me", "mei", "men", "meng", "mi", "mian", "miao", "mie", "min", "ming", "miu", "mo", "mou", "mu",
-		"na", "nai", "nan", "nang", "nao", "ne", "nei", "nen", "neng", "ni", "nian", "niang", "niao", "nie", "nin", "ning", "niu", "nong", "nu", "nuan", "nue", "nuo", "nv",
-		"o", "ou",
-		"pa", "pai", "pan", "pang", "pao", "pei", "pen", "peng", "pi", "pian", "piao", "pie", "pin", "ping", "po", "pou", "pu",
-		"qi", "qia", "qian", "qiang", "qiao", "qie", "qin", "qing", "qiong", "qiu", "qu", "quan", "que", "qun",
-		"ran", "rang", "rao", "re", "ren", "reng", "ri", "rong", "rou", "ru", "ruan", "rui", "run", "ruo",
-		"sa", "sai", "san", "sang", "sao", "se", "sen", "seng", "sha", "shai", "shan", "shang", "shao", "she", "shen", "sheng", "shi", "shou", "shu", "shua", "shuai", "shuan", "shuang", "shui", "shun", "shuo", "si", "song", "sou", "su", "suan", "sui", "sun", "suo",
-		"ta", "tai", "tan", "tang", "tao", "te", "teng", "ti", "tian", "tiao", "tie", "ting", "tong", "tou", "tu", "tuan", "tui", "tun", "tuo",
-		"wa", "wai", "wan", "wang", "wei", "wen", "weng", "wo", "wu",
-		"xi", "xia", "xian", "xiang", "xiao", "xie", "xin", "xing", "xiong", "xiu", "xu", "xuan", "xue", "xun",
-		"ya", "yan", "yang", "yao", "ye", "yi", "yin", "ying", "yo", "yong", "you", "yu", "yuan", "yue", "yun",
-		"za", "zai", "zan", "zang", "zao", "ze", "zei", "zen", "zeng", "zha", "zhai", "zhan", "zhang", "zhao", "zhe", "zhen", "zheng", "zhi", "zhong", "zhou", "zhu", "zhua", "zhuai", "zhuan", "zhuang", "zhui", "zhun", "zhuo", "zi", "zong", "zou", "zu", "zuan", "zui", "zun", "zuo",
-	}
-
-	for _, valid := range validPinyins {
-		if valid == pinyin {
-			return true
+// pinyinInitial 返回拼音的声母，零声母音节返回空字符串
+func (c *Chinese) pinyinInitial(pinyin string) string {
+	base, _ := c.splitTone(pinyin)
+	base = strings.ToLower(base)
+	for _, initial := range pinyinInitials {
+		// 单独的 m、n、ng 等为成音节鼻音，不拆出声母
+		if strings.HasPrefix(base, initial) && len(base) > len(initial) && base != "ng" {
+			return initial
 		}
 	}
-	return false
+	return ""
 }
 
 // 全局实例 - 使用完整数据
@@ -290,6 +238,11 @@ func ToPinyin(text string, mode ConvertMode, separator string, splitNonChinese b
 	return defaultChinese.ToPinyin(text, mode, separator, splitNonChinese)
 }
 
+// ToPinyinString 全局函数：汉字转拼音（返回字符串）
+func ToPinyinString(text string, mode ConvertMode, separator string, splitNonChinese bool) (string, error) {
+	return defaultChinese.ToPinyinString(text, mode, separator, splitNonChinese)
+}
+
 // SplitPinyin 全局函数：拼音分词（返回字符串）
 func SplitPinyin(pinyin string) ([]string, error) {
 	return defaultChinese.SplitPinyin(pinyin)
@@ -1,6 +1,10 @@
 package zhkit
 
 import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -61,6 +65,1200 @@ func TestToPinyin(t *testing.T) {
 	}
 }
 
+func TestToPinyinTone(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	tests := []struct {
+		name           string
+		text           string
+		expectedSound  []string
+		expectedNumber []string
+	}{
+		{
+			name:           "一声",
+			text:           "中",
+			expectedSound:  []string{"zhōng", "zhòng"},
+			expectedNumber: []string{"zhong1", "zhong4"},
+		},
+		{
+			name:           "轻声",
+			text:           "的",
+			expectedSound:  []string{"dí", "dì", "de"},
+			expectedNumber: []string{"di2", "di4", "de5"},
+		},
+		{
+			name:           "ü 转 v",
+			text:           "绿",
+			expectedSound:  []string{"lǜ", "lù"},
+			expectedNumber: []string{"lv4", "lu4"},
+		},
+		{
+			name:           "üe 标调",
+			text:           "略",
+			expectedSound:  []string{"lüè"},
+			expectedNumber: []string{"lve4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := chinese.ToPinyin(tt.text, ModePinyinSound|ModePinyinSoundNumber, " ", false)
+			if err != nil {
+				t.Errorf("ToPinyin() error = %v, expected success", err)
+				return
+			}
+			if got := strings.Join(result.PinyinSound[0], ","); got != strings.Join(tt.expectedSound, ",") {
+				t.Errorf("ToPinyin() PinyinSound = %v, expected %v", got, tt.expectedSound)
+			}
+			if got := strings.Join(result.PinyinSoundNumber[0], ","); got != strings.Join(tt.expectedNumber, ",") {
+				t.Errorf("ToPinyin() PinyinSoundNumber = %v, expected %v", got, tt.expectedNumber)
+			}
+		})
+	}
+
+	// 数字声调转回声调符号
+	for input, expected := range map[string]string{"gui3": "guǐ", "liu2": "liú", "nv3": "nǚ", "hao": "hao"} {
+		if got := chinese.addToneMarks(input); got != expected {
+			t.Errorf("addToneMarks(%s) = %s, expected %s", input, got, expected)
+		}
+	}
+}
+
+func TestToPinyinASCII(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	result, err := chinese.ToPinyin("中国绿", ModePinyin|ModePinyinASCII, " ", false)
+	if err != nil {
+		t.Fatalf("ToPinyin() error = %v, expected success", err)
+	}
+	if got := fmt.Sprint(result.Pinyin); got != "[[zhong] [guo] [lü lu]]" {
+		t.Errorf("ToPinyin() Pinyin = %s", got)
+	}
+	if got := fmt.Sprint(result.PinyinASCII); got != "[[zhong] [guo] [lv lu]]" {
+		t.Errorf("ToPinyin() PinyinASCII = %s", got)
+	}
+
+	chinese.SetUmlautReplacement("yu")
+	result, _ = chinese.ToPinyin("女", ModePinyinASCII, " ", false)
+	if got := fmt.Sprint(result.PinyinASCII); got != "[[nyu ru]]" {
+		t.Errorf("ToPinyin() PinyinASCII with replacement = %s", got)
+	}
+
+	// 空字符串恢复默认写法
+	chinese.SetUmlautReplacement("")
+	result, _ = chinese.ToPinyin("女", ModePinyinASCII, " ", false)
+	if got := fmt.Sprint(result.PinyinASCII); got != "[[nv ru]]" {
+		t.Errorf("ToPinyin() PinyinASCII after resetting replacement = %s", got)
+	}
+}
+
+func TestToPinyinFirstAndInitial(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	result, err := chinese.ToPinyin("张爱欧阳", ModePinyinFirst|ModePinyinInitial, " ", false)
+	if err != nil {
+		t.Fatalf("ToPinyin() error = %v, expected success", err)
+	}
+	if got := fmt.Sprint(result.PinyinFirst); got != "[[z] [a] [o] [y]]" {
+		t.Errorf("ToPinyin() PinyinFirst = %s", got)
+	}
+	if got := fmt.Sprint(result.PinyinInitial); got != "[[zh] [] [] []]" {
+		t.Errorf("ToPinyin() PinyinInitial = %s", got)
+	}
+
+	// 零声母音节保留空位，与原文逐字对应
+	tests := []struct {
+		text     string
+		options  *PinyinOptions
+		expected string
+	}{
+		{"一点儿", &PinyinOptions{Mode: ModePinyinInitial, Separator: "-"}, "-d-"},
+		{"玩儿", &PinyinOptions{Mode: ModePinyinInitial, Separator: "-"}, "-"},
+		{"玩儿", &PinyinOptions{Mode: ModePinyinInitial, Separator: "-", Erhua: true}, ""},
+		{"张爱欧阳", &PinyinOptions{Mode: ModePinyinInitial, Separator: ","}, "zh,,,"},
+	}
+	for _, tt := range tests {
+		if got, err := chinese.ToPinyinStringWithOptions(tt.text, tt.options); err != nil || got != tt.expected {
+			t.Errorf("ToPinyinStringWithOptions(%q) = %q, %v, expected %q", tt.text, got, err, tt.expected)
+		}
+	}
+}
+
+func TestToPinyinPhrase(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "银行",
+			text:     "银行",
+			expected: "[[yín] [háng]]",
+		},
+		{
+			name:     "行走",
+			text:     "行走",
+			expected: "[[xíng] [zǒu]]",
+		},
+		{
+			name:     "最长匹配",
+			text:     "人行道",
+			expected: "[[rén] [xíng] [dào]]",
+		},
+		{
+			name:     "未匹配的字保留全部读音",
+			text:     "行",
+			expected: "[[háng xíng]]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := chinese.ToPinyin(tt.text, ModePinyinSound, " ", false)
+			if err != nil {
+				t.Errorf("ToPinyin() error = %v, expected success", err)
+				return
+			}
+			if got := fmt.Sprint(result.PinyinSound); got != tt.expected {
+				t.Errorf("ToPinyin() = %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+
+	// 自定义词组
+	if err := chinese.AddPhrase("行行", "háng", "háng"); err != nil {
+		t.Fatalf("AddPhrase() error = %v", err)
+	}
+	result, _ := chinese.ToPinyin("行行", ModePinyinSoundNumber, " ", false)
+	if got := fmt.Sprint(result.PinyinSoundNumber); got != "[[hang2] [hang2]]" {
+		t.Errorf("ToPinyin() with custom phrase = %s", got)
+	}
+
+	if err := chinese.AddPhrase("银行", "yín"); err == nil {
+		t.Errorf("AddPhrase() expected error for mismatched pinyin count")
+	}
+}
+
+func TestToPinyinNonChinese(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	result, err := chinese.ToPinyin("iPhone13手机", ModePinyin, " ", false)
+	if err != nil {
+		t.Fatalf("ToPinyin() error = %v, expected success", err)
+	}
+	if got := fmt.Sprint(result.Pinyin); got != "[[iPhone13] [shou] [ji]]" {
+		t.Errorf("ToPinyin() splitNonChinese=false = %s", got)
+	}
+
+	result, _ = chinese.ToPinyin("iOS手机", ModePinyin, " ", true)
+	if got := fmt.Sprint(result.Pinyin); got != "[[i] [O] [S] [shou] [ji]]" {
+		t.Errorf("ToPinyin() splitNonChinese=true = %s", got)
+	}
+}
+
+func TestToPinyinString(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	tests := []struct {
+		name      string
+		text      string
+		mode      ConvertMode
+		separator string
+		expected  string
+	}{
+		{
+			name:      "默认分隔符",
+			text:      "中国人",
+			mode:      ModePinyin,
+			separator: "",
+			expected:  "zhong guo ren",
+		},
+		{
+			name:      "自定义分隔符",
+			text:      "中国人",
+			mode:      ModePinyinSoundNumber,
+			separator: "-",
+			expected:  "zhong1-guo2-ren2",
+		},
+		{
+			name:      "混合中英文",
+			text:      "iPhone 手机",
+			mode:      ModePinyin,
+			separator: " ",
+			expected:  "iPhone shou ji",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := chinese.ToPinyinString(tt.text, tt.mode, tt.separator, false)
+			if err != nil {
+				t.Errorf("ToPinyinString() error = %v, expected success", err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("ToPinyinString() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+
+	if _, err := chinese.ToPinyinString("中国", ModePinyin|ModePinyinFirst, " ", false); err == nil {
+		t.Errorf("ToPinyinString() expected error for combined modes")
+	}
+}
+
+func TestToPinyinWithOptions(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	tests := []struct {
+		name     string
+		text     string
+		options  *PinyinOptions
+		expected string
+	}{
+		{
+			name:     "默认选项",
+			text:     "中国",
+			options:  nil,
+			expected: "[[zhong] [guo]]",
+		},
+		{
+			name:     "多音字取第一个读音",
+			text:     "行",
+			options:  &PinyinOptions{Polyphone: PolyphoneFirst},
+			expected: "[[hang]]",
+		},
+		{
+			name:     "删除非中文字符",
+			text:     "你好, world",
+			options:  &PinyinOptions{NonChinese: CharRemove},
+			expected: "[[ni] [hao]]",
+		},
+		{
+			name:     "替换未知汉字",
+			text:     "中\U000200d1",
+			options:  &PinyinOptions{Unknown: CharReplace, Replacement: "?"},
+			expected: "[[zhong] [?]]",
+		},
+		{
+			name:     "自定义 ü 写法",
+			text:     "女",
+			options:  &PinyinOptions{Mode: ModePinyinASCII, UmlautReplacement: "yu", Polyphone: PolyphoneFirst},
+			expected: "[[nyu]]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := chinese.ToPinyinWithOptions(tt.text, tt.options)
+			if err != nil {
+				t.Errorf("ToPinyinWithOptions() error = %v, expected success", err)
+				return
+			}
+			got := fmt.Sprint(result.Pinyin)
+			if result.PinyinASCII != nil {
+				got = fmt.Sprint(result.PinyinASCII)
+			}
+			if got != tt.expected {
+				t.Errorf("ToPinyinWithOptions() = %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+
+	str, err := chinese.ToPinyinStringWithOptions("你好世界", &PinyinOptions{Mode: ModePinyinFirst, Separator: "/"})
+	if err != nil || str != "n/h/s/j" {
+		t.Errorf("ToPinyinStringWithOptions() = %q, %v", str, err)
+	}
+}
+
+func TestToPinyinTokens(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	text := "去银行abc好"
+	tokens, err := chinese.ToPinyinTokens(text, &PinyinOptions{Mode: ModePinyinSound})
+	if err != nil {
+		t.Fatalf("ToPinyinTokens() error = %v, expected success", err)
+	}
+
+	expected := []PinyinToken{
+		{Text: "去", Start: 0, End: 3, RuneStart: 0, RuneEnd: 1, IsHan: true, Readings: []string{"qù"}},
+		{Text: "银", Start: 3, End: 6, RuneStart: 1, RuneEnd: 2, IsHan: true, Readings: []string{"yín"}},
+		{Text: "行", Start: 6, End: 9, RuneStart: 2, RuneEnd: 3, IsHan: true, Readings: []string{"háng"}},
+		{Text: "abc", Start: 9, End: 12, RuneStart: 3, RuneEnd: 6, IsHan: false, Readings: []string{"abc"}},
+		{Text: "好", Start: 12, End: 15, RuneStart: 6, RuneEnd: 7, IsHan: true, Readings: []string{"hǎo", "hào"}},
+	}
+	if fmt.Sprint(tokens) != fmt.Sprint(expected) {
+		t.Errorf("ToPinyinTokens() = %v, expected %v", tokens, expected)
+	}
+	for _, token := range tokens {
+		if text[token.Start:token.End] != token.Text {
+			t.Errorf("ToPinyinTokens() offsets of %q do not match source", token.Text)
+		}
+	}
+
+	if _, err := chinese.ToPinyinTokens(text, &PinyinOptions{Mode: ModePinyin | ModePinyinFirst}); err == nil {
+		t.Errorf("ToPinyinTokens() expected error for combined modes")
+	}
+
+	// 无调模式下只差声调的读音只保留一个
+	tokens, _ = chinese.ToPinyinTokens("好", &PinyinOptions{Mode: ModePinyin})
+	if len(tokens) != 1 || fmt.Sprint(tokens[0].Readings) != "[hao]" {
+		t.Errorf("ToPinyinTokens(好) = %v, expected readings [hao]", tokens)
+	}
+}
+
+func TestPinyinCombinations(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	collect := func(text string, options *CombinationOptions) []string {
+		seq, err := chinese.PinyinCombinations(text, options)
+		if err != nil {
+			t.Fatalf("PinyinCombinations() error = %v, expected success", err)
+		}
+		var results []string
+		for combination := range seq {
+			results = append(results, strings.Join(combination, " "))
+		}
+		return results
+	}
+
+	got := collect("单行道", nil)
+	if len(got) != 6 || got[0] != "dan hang dao" {
+		t.Errorf("PinyinCombinations(单行道) = %v", got)
+	}
+
+	// 词组读音优先
+	got = collect("银行", nil)
+	if fmt.Sprint(got) != "[yin hang yin xing]" {
+		t.Errorf("PinyinCombinations(银行) = %v", got)
+	}
+
+	// 排序与上限
+	got = collect("行行行", &CombinationOptions{Ranked: true, Limit: 5})
+	expected := "[hang hang hang hang hang xing hang xing hang xing hang hang hang xing xing]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("PinyinCombinations(行行行, ranked) = %v, expected %s", got, expected)
+	}
+
+	got = collect("行行行", &CombinationOptions{Limit: 4})
+	expected = "[hang hang hang hang hang xing hang xing hang hang xing xing]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("PinyinCombinations(行行行) = %v, expected %s", got, expected)
+	}
+}
+
+func TestNameToPinyin(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "单姓",
+			input:    "单田芳",
+			expected: "[[shàn] [tián] [fāng]]",
+		},
+		{
+			name:     "曾",
+			input:    "曾国藩",
+			expected: "[[zēng] [guó] [fān]]",
+		},
+		{
+			name:     "复姓",
+			input:    "尉迟恭",
+			expected: "[[yù] [chí] [gōng]]",
+		},
+		{
+			name:     "名字不使用姓氏读音",
+			input:    "张曾",
+			expected: "[[zhāng] [zēng céng]]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := chinese.NameToPinyin(tt.input, &PinyinOptions{Mode: ModePinyinSound})
+			if err != nil {
+				t.Errorf("NameToPinyin() error = %v, expected success", err)
+				return
+			}
+			if got := fmt.Sprint(result.PinyinSound); got != tt.expected {
+				t.Errorf("NameToPinyin() = %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+
+	if err := chinese.AddSurname("长", "zhǎng"); err != nil {
+		t.Fatalf("AddSurname() error = %v", err)
+	}
+	result, _ := chinese.NameToPinyin("长江", nil)
+	if got := fmt.Sprint(result.Pinyin); got != "[[zhang] [jiang]]" {
+		t.Errorf("NameToPinyin() with custom surname = %s", got)
+	}
+}
+
+func TestToneSandhi(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	tests := []struct {
+		name     string
+		text     string
+		mode     ConvertMode
+		expected string
+	}{
+		{name: "三声变调", text: "你好", mode: ModePinyinSound, expected: "ní hǎo"},
+		{name: "连续三声", text: "展览馆", mode: ModePinyinSoundNumber, expected: "zhan2 lan2 guan3"},
+		{name: "一在四声前", text: "一个", mode: ModePinyinSound, expected: "yí gè"},
+		{name: "一在一声前", text: "一天", mode: ModePinyinSound, expected: "yì tiān"},
+		{name: "序数不变调", text: "第一", mode: ModePinyinSound, expected: "dì yī"},
+		{name: "序数后接名词不变调", text: "第一次", mode: ModePinyinSound, expected: "dì yī cì"},
+		{name: "逐位读的数字不变调", text: "一九八四", mode: ModePinyinSound, expected: "yī jiǔ bā sì"},
+		{name: "数字中不变调", text: "十一月", mode: ModePinyinSound, expected: "shí yī yuè"},
+		{name: "百千前仍变调", text: "一千一百", mode: ModePinyinSound, expected: "yì qiān yì bǎi"},
+		{name: "不在四声前", text: "不要", mode: ModePinyinSoundNumber, expected: "bu2 yao4"},
+		{name: "A不A", text: "好不好", mode: ModePinyinSound, expected: "hǎo bu hǎo"},
+		{name: "叠词轻声", text: "姐姐", mode: ModePinyinSound, expected: "jiě jie"},
+		{name: "不影响无调模式", text: "你好", mode: ModePinyin, expected: "ni hao"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := chinese.ToPinyinStringWithOptions(tt.text, &PinyinOptions{
+				Mode:                 tt.mode,
+				ToneSandhi:           true,
+				NeutralReduplication: true,
+			})
+			if err != nil {
+				t.Errorf("ToPinyinStringWithOptions() error = %v, expected success", err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("ToPinyinStringWithOptions() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+
+	// 默认保留字典声调
+	result, _ := chinese.ToPinyinString("你好", ModePinyinSound, " ", false)
+	if result != "nǐ hǎo" {
+		t.Errorf("ToPinyinString() without sandhi = %q", result)
+	}
+}
+
+func TestErhua(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "儿化", text: "一点儿", expected: "yī diǎnr"},
+		{name: "词组词典中的儿化", text: "玩儿", expected: "wánr"},
+		{name: "哪儿", text: "哪儿", expected: "nǎr"},
+		{name: "不在词组中的儿化", text: "这点儿", expected: "zhè diǎnr"},
+		{name: "老头儿", text: "老头儿", expected: "lǎo tóur"},
+		{name: "味儿", text: "味儿", expected: "wèir"},
+		{name: "画儿", text: "画儿", expected: "huàr"},
+		{name: "句中的儿化", text: "我们去哪儿玩儿", expected: "wǒ men qù nǎr wánr"},
+		{name: "儿子", text: "儿子", expected: "ér zi"},
+		{name: "二儿子", text: "二儿子", expected: "èr ér zi"},
+		{name: "女儿", text: "女儿", expected: "nǚ ér"},
+		{name: "育儿", text: "育儿", expected: "yù ér"},
+		{name: "儿歌", text: "唱儿歌", expected: "chàng ér gē"},
+		{name: "句首的儿", text: "儿", expected: "ér"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := chinese.ToPinyinStringWithOptions(tt.text, &PinyinOptions{Mode: ModePinyinSound, Erhua: true})
+			if err != nil {
+				t.Errorf("ToPinyinStringWithOptions() error = %v, expected success", err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("ToPinyinStringWithOptions() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+
+	tokens, _ := chinese.ToPinyinTokens("一点儿", &PinyinOptions{Mode: ModePinyin, Erhua: true})
+	if len(tokens) != 2 || tokens[1].Text != "点儿" || tokens[1].RuneEnd != 3 || tokens[1].Readings[0] != "dianr" {
+		t.Errorf("ToPinyinTokens() with erhua = %v", tokens)
+	}
+}
+
+func TestZhuyin(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	tests := []struct {
+		name   string
+		pinyin string
+		zhuyin string
+		input  string // 与 pinyin 不同的拼音输入
+	}{
+		{name: "基本", pinyin: "zhōng guó", zhuyin: "ㄓㄨㄥ ㄍㄨㄛˊ"},
+		{name: "整体认读", pinyin: "zhī chí shǐ rì zì cí sì", zhuyin: "ㄓ ㄔˊ ㄕˇ ㄖˋ ㄗˋ ㄘˊ ㄙˋ"},
+		{name: "ü", pinyin: "lǜ nǚ yú xué juān qún", zhuyin: "ㄌㄩˋ ㄋㄩˇ ㄩˊ ㄒㄩㄝˊ ㄐㄩㄢ ㄑㄩㄣˊ"},
+		{name: "零声母", pinyin: "yī wǔ yǒu wèi wēng yōng", zhuyin: "ㄧ ㄨˇ ㄧㄡˇ ㄨㄟˋ ㄨㄥ ㄩㄥ"},
+		{name: "缩写韵母", pinyin: "liù guì lùn xióng", zhuyin: "ㄌㄧㄡˋ ㄍㄨㄟˋ ㄌㄨㄣˋ ㄒㄩㄥˊ"},
+		{name: "轻声和儿化", pinyin: "de diǎnr èr", zhuyin: "˙ㄉㄜ ㄉㄧㄢˇㄦ ㄦˋ", input: "de5 diǎnr èr"},
+		{name: "数字声调", pinyin: "nǐ hǎo", zhuyin: "ㄋㄧˇ ㄏㄠˇ", input: "ni3 hao3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pinyin := tt.pinyin
+			if tt.input != "" {
+				pinyin = tt.input
+			}
+			zhuyin, err := chinese.PinyinToZhuyin(pinyin)
+			if err != nil || zhuyin != tt.zhuyin {
+				t.Errorf("PinyinToZhuyin(%q) = %q, %v, expected %q", pinyin, zhuyin, err, tt.zhuyin)
+			}
+
+			back, err := chinese.ZhuyinToPinyin(tt.zhuyin)
+			if err != nil || back != tt.pinyin {
+				t.Errorf("ZhuyinToPinyin(%q) = %q, %v, expected %q", tt.zhuyin, back, err, tt.pinyin)
+			}
+		})
+	}
+
+	if _, err := chinese.PinyinToZhuyin("abc"); err == nil {
+		t.Errorf("PinyinToZhuyin() expected error for invalid pinyin")
+	}
+
+	result, err := chinese.ToPinyinStringWithOptions("中国", &PinyinOptions{Mode: ModeZhuyin})
+	if err != nil || result != "ㄓㄨㄥ ㄍㄨㄛˊ" {
+		t.Errorf("ToPinyinStringWithOptions(ModeZhuyin) = %q, %v", result, err)
+	}
+}
+
+func TestRomanization(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	tests := []struct {
+		name      string
+		pinyin    string
+		style     ToneStyle
+		wadeGiles string
+		yale      string
+	}{
+		{name: "数字声调", pinyin: "běi jīng", style: ToneNumber, wadeGiles: "pei3 ching1", yale: "bei3 jing1"},
+		{name: "上标声调", pinyin: "qing1 hua2", style: ToneSuperscript, wadeGiles: "ch'ing¹ hua²", yale: "ching¹ hwa²"},
+		{name: "不标声调", pinyin: "xióng māo", style: ToneNone, wadeGiles: "hsiung mao", yale: "syung mau"},
+		{name: "整体认读", pinyin: "zhī chí shì rì zì cí sī", style: ToneNone, wadeGiles: "chih ch'ih shih jih tzŭ tz'ŭ ssŭ", yale: "jr chr shr r dz tsz sz"},
+		{name: "ü", pinyin: "lǜ nüè jué yuán", style: ToneNone, wadeGiles: "lü nüeh chüeh yüan", yale: "lyu nywe jywe ywan"},
+		{name: "韵母缩写", pinyin: "guì liù lùn duō gē", style: ToneNone, wadeGiles: "kuei liu lun to ko", yale: "gwei lyou lwun dwo ge"},
+		{name: "零声母", pinyin: "yī yǒu wēng yè ér", style: ToneNone, wadeGiles: "i yu weng yeh êrh", yale: "yi you weng ye er"},
+		{name: "轻声", pinyin: "de5", style: ToneNumber, wadeGiles: "tê", yale: "de"},
+		{name: "儿化", pinyin: "diǎnr wánr", style: ToneNumber, wadeGiles: "tienrh3 wanrh2", yale: "dyanr3 wanr2"},
+		{name: "鼻音", pinyin: "ǹg ń m̄ hm hng", style: ToneNumber, wadeGiles: "ng4 n2 m1 hm hng", yale: "ng4 n2 m1 hm hng"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wadeGiles, err := chinese.PinyinToWadeGiles(tt.pinyin, tt.style)
+			if err != nil || wadeGiles != tt.wadeGiles {
+				t.Errorf("PinyinToWadeGiles(%q) = %q, %v, expected %q", tt.pinyin, wadeGiles, err, tt.wadeGiles)
+			}
+			yale, err := chinese.PinyinToYale(tt.pinyin, tt.style)
+			if err != nil || yale != tt.yale {
+				t.Errorf("PinyinToYale(%q) = %q, %v, expected %q", tt.pinyin, yale, err, tt.yale)
+			}
+		})
+	}
+
+	if _, err := chinese.PinyinToWadeGiles("bjing", ToneNumber); err == nil {
+		t.Errorf("PinyinToWadeGiles() expected error for invalid pinyin")
+	}
+
+	result, err := chinese.ToPinyinStringWithOptions("北京", &PinyinOptions{Mode: ModeWadeGiles, Separator: "-"})
+	if err != nil || result != "pei3-ching1" {
+		t.Errorf("ToPinyinStringWithOptions(ModeWadeGiles) = %q, %v", result, err)
+	}
+	result, err = chinese.ToPinyinStringWithOptions("北京", &PinyinOptions{Mode: ModeYale, ToneStyle: ToneSuperscript})
+	if err != nil || result != "bei³ jing¹" {
+		t.Errorf("ToPinyinStringWithOptions(ModeYale) = %q, %v", result, err)
+	}
+	result, err = chinese.ToPinyinStringWithOptions("一点儿", &PinyinOptions{Mode: ModeWadeGiles, Erhua: true})
+	if err != nil || result != "i1 tienrh3" {
+		t.Errorf("ToPinyinStringWithOptions(ModeWadeGiles, Erhua) = %q, %v", result, err)
+	}
+	result, err = chinese.ToPinyinStringWithOptions("嗯呣", &PinyinOptions{Mode: ModeYale})
+	if err != nil || result != "ng4 m2" {
+		t.Errorf("ToPinyinStringWithOptions(ModeYale) = %q, %v", result, err)
+	}
+}
+
+func TestIPA(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	tests := []struct {
+		name     string
+		pinyin   string
+		style    ToneStyle
+		expected string
+	}{
+		{name: "调符号", pinyin: "zhōng guó", style: ToneLetter, expected: "ʈʂʊŋ˥ kwo˧˥"},
+		{name: "调值", pinyin: "nǐ hǎo", style: ToneNumber, expected: "ni214 xɑʊ214"},
+		{name: "上标调值", pinyin: "mài", style: ToneSuperscript, expected: "maɪ⁵¹"},
+		{name: "舌尖元音", pinyin: "zhī cí shi rì sī", style: ToneNone, expected: "ʈʂɻ̩ tsʰɹ̩ ʂɻ̩ ʐɻ̩ sɹ̩"},
+		{name: "ü", pinyin: "lǜ yuè quán jūn xū", style: ToneNone, expected: "ly ɥɛ tɕʰɥɛn tɕyn ɕy"},
+		{name: "韵母", pinyin: "biān liù guì lùn xióng wēng bō", style: ToneNone, expected: "pjɛn ljoʊ kweɪ lwən ɕjʊŋ wɤŋ pwo"},
+		{name: "轻声和儿化", pinyin: "de5 diǎnr", style: ToneLetter, expected: "tɤ tjɛnɻ˨˩˦"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ipa, err := chinese.PinyinToIPA(tt.pinyin, tt.style)
+			if err != nil || ipa != tt.expected {
+				t.Errorf("PinyinToIPA(%q) = %q, %v, expected %q", tt.pinyin, ipa, err, tt.expected)
+			}
+		})
+	}
+
+	for _, pinyin := range []string{"bv", "bjing", "fi", "zhong guox"} {
+		if ipa, err := chinese.PinyinToIPA(pinyin, ToneNone); err == nil {
+			t.Errorf("PinyinToIPA(%q) = %q, expected error for invalid pinyin", pinyin, ipa)
+		}
+	}
+
+	result, err := chinese.ToPinyinStringWithOptions("中国", &PinyinOptions{Mode: ModeIPA, ToneStyle: ToneLetter})
+	if err != nil || result != "ʈʂʊŋ˥ kwo˧˥" {
+		t.Errorf("ToPinyinStringWithOptions(ModeIPA) = %q, %v", result, err)
+	}
+}
+
+func TestPinyinToHanzi(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	tests := []struct {
+		name     string
+		pinyin   string
+		first    string
+		contains []string
+		excludes []string
+	}{
+		{name: "无调", pinyin: "zhong", first: "中", contains: []string{"钟", "重", "众"}},
+		{name: "声调符号", pinyin: "zhōng", first: "中", contains: []string{"钟"}, excludes: []string{"重", "众"}},
+		{name: "数字声调", pinyin: "zhong4", contains: []string{"重", "众"}, excludes: []string{"钟"}},
+		{name: "轻声", pinyin: "de5", first: "的"},
+		{name: "ü 写作 v", pinyin: "lv4", first: "律", contains: []string{"绿"}},
+		{name: "lüe 写作 lue", pinyin: "lue", first: "略"},
+		{name: "nüe 写作 nue", pinyin: "nue4", first: "虐"},
+		{name: "nüe 写作 nve", pinyin: "nve", first: "虐"},
+		{name: "次要读音靠后", pinyin: "ma", first: "马"},
+		{name: "极常用字的次要读音", pinyin: "de", first: "的"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chars, err := chinese.PinyinToHanzi(tt.pinyin)
+			if err != nil || len(chars) == 0 {
+				t.Fatalf("PinyinToHanzi(%q) = %v, %v, expected results", tt.pinyin, chars, err)
+			}
+			if tt.first != "" && chars[0] != tt.first {
+				t.Errorf("PinyinToHanzi(%q)[0] = %q, expected %q", tt.pinyin, chars[0], tt.first)
+			}
+			joined := strings.Join(chars, "")
+			for _, char := range tt.contains {
+				if !strings.Contains(joined, char) {
+					t.Errorf("PinyinToHanzi(%q) missing %q", tt.pinyin, char)
+				}
+			}
+			for _, char := range tt.excludes {
+				if strings.Contains(joined, char) {
+					t.Errorf("PinyinToHanzi(%q) should not contain %q", tt.pinyin, char)
+				}
+			}
+		})
+	}
+
+	// 常用字生僻的次要读音排在以该音为首选读音的常用字之后
+	order := []struct {
+		pinyin string
+		before string
+		after  string
+	}{
+		{"ma", "吗", "么"},
+		{"e", "饿", "阿"},
+		{"xian", "先", "见"},
+		{"dou", "豆", "读"},
+	}
+	for _, tt := range order {
+		chars, _ := chinese.PinyinToHanzi(tt.pinyin)
+		if before, after := slices.Index(chars, tt.before), slices.Index(chars, tt.after); before < 0 || after < before {
+			t.Errorf("PinyinToHanzi(%q): %s at %d, expected before %s at %d", tt.pinyin, tt.before, before, tt.after, after)
+		}
+	}
+
+	// README 示例的顺序
+	readme := []struct {
+		pinyin   string
+		expected []string
+	}{
+		{"zhōng", []string{"中", "终", "钟", "忠"}},
+		{"lv4", []string{"律", "虑", "绿"}},
+	}
+	for _, tt := range readme {
+		chars, _ := chinese.PinyinToHanzi(tt.pinyin)
+		if len(chars) < len(tt.expected) || !slices.Equal(chars[:len(tt.expected)], tt.expected) {
+			t.Errorf("PinyinToHanzi(%q) = %v, expected to start with %v", tt.pinyin, chars, tt.expected)
+		}
+	}
+
+	if _, err := chinese.PinyinToHanzi(""); err == nil {
+		t.Errorf("PinyinToHanzi() expected error for empty input")
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	tests := []struct {
+		name     string
+		input    string
+		first    string
+		consumed int
+		contains string
+	}{
+		{name: "整句", input: "woaibeijing", first: "我爱北京", consumed: 11, contains: "我"},
+		{name: "简拼", input: "bjdx", first: "北京大学", consumed: 4, contains: "北京"},
+		{name: "混合简拼", input: "beijdx", first: "北京大学", consumed: 6},
+		{name: "单个音节", input: "shi", first: "是", consumed: 3},
+		{name: "隔音符号", input: "xi'an", first: "西安", consumed: 5, contains: "西"},
+		{name: "ü 写作 ue", input: "lue", first: "略", consumed: 3},
+		{name: "大小写和前导空白", input: "  WoAi", first: "我爱", consumed: 6, contains: "我"},
+		{name: "空白作为音节边界", input: "xi an", first: "西安", consumed: 5, contains: "西"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates, err := chinese.Candidates(tt.input)
+			if err != nil || len(candidates) == 0 {
+				t.Fatalf("Candidates(%q) = %v, %v, expected results", tt.input, candidates, err)
+			}
+			if candidates[0].Text != tt.first || candidates[0].Consumed != tt.consumed {
+				t.Errorf("Candidates(%q)[0] = %+v, expected %q consuming %d", tt.input, candidates[0], tt.first, tt.consumed)
+			}
+			if len(candidates[0].Pinyin) != len([]rune(candidates[0].Text)) {
+				t.Errorf("Candidates(%q)[0].Pinyin = %v, expected one reading per char", tt.input, candidates[0].Pinyin)
+			}
+			if tt.contains != "" {
+				found := false
+				for _, candidate := range candidates {
+					found = found || candidate.Text == tt.contains
+				}
+				if !found {
+					t.Errorf("Candidates(%q) missing %q", tt.input, tt.contains)
+				}
+			}
+		})
+	}
+
+	// 消耗长度为原始输入中的位置，剩余部分可以直接继续查询
+	if candidates, _ := chinese.Candidates(" Bei Jing ren"); len(candidates) < 2 || candidates[1].Text != "北京" || candidates[1].Consumed != 10 {
+		t.Errorf("Candidates(\" Bei Jing ren\") = %v, expected 北京 consuming 10 after the sentence", candidates)
+	}
+
+	for _, input := range []string{"", "'", "''", "  ", "abc1", "ii", "wo,ai"} {
+		if _, err := chinese.Candidates(input); err == nil {
+			t.Errorf("Candidates(%q) expected error", input)
+		}
+	}
+
+	// 自成音节的鼻音只在单独成段时作为音节，yangsheng 不拆出 嗯（ng）
+	for _, input := range []string{"yangsheng", "hengheng"} {
+		for _, options := range []*CandidateOptions{nil, {Fuzzy: FuzzyAll}} {
+			candidates, _ := chinese.CandidatesWithOptions(input, options)
+			for _, candidate := range candidates {
+				for _, py := range candidate.Pinyin {
+					if base, _ := chinese.splitTone(py); slices.Contains(syllabicNasals, strings.ToLower(base)) {
+						t.Errorf("CandidatesWithOptions(%q, %+v) = %+v, expected no syllabic nasal", input, options, candidate)
+					}
+				}
+			}
+		}
+	}
+	if candidates, _ := chinese.Candidates("xi'ng"); len(candidates) == 0 || candidates[0].Text != "西嗯" {
+		t.Errorf("Candidates(xi'ng) = %v, expected 西嗯 first", candidates)
+	}
+
+	// 输入法词库只用于组词，不影响汉字转拼音
+	if candidates, _ := chinese.Candidates("pengyou"); len(candidates) == 0 || candidates[0].Text != "朋友" {
+		t.Errorf("Candidates(pengyou) = %v, expected 朋友 first", candidates)
+	}
+	if result, _ := chinese.ToPinyinString("朋友知道", ModePinyinSound, " ", false); result != "péng yǒu zhī dào" {
+		t.Errorf("ToPinyinString(朋友知道) = %q, expected dictionary tones", result)
+	}
+	if err := chinese.AddWord("拼音工具", "pin1", "yin1", "gong1", "ju4"); err != nil {
+		t.Fatalf("AddWord() error = %v", err)
+	}
+	if candidates, _ := chinese.Candidates("pygj"); len(candidates) == 0 || candidates[0].Text != "拼音工具" {
+		t.Errorf("Candidates(pygj) = %v, expected 拼音工具 first", candidates)
+	}
+
+	// 模糊音：输入能按准确读音完整切分时准确匹配在前，模糊音匹配在后
+	fuzzy := []struct {
+		input    string
+		rule     FuzzyRule
+		first    string
+		contains string
+	}{
+		{"tin", FuzzyInIng, "听", "听"},
+		{"tinshuo", FuzzyInIng, "听说", "听说"},
+		{"zongguo", FuzzyZZh, "总国", "中国"},
+		{"lanjing", FuzzyNL, "兰经", "南京"},
+		{"yangsheng", FuzzyAll, "样生", "颜色"},
+	}
+	for _, tt := range fuzzy {
+		candidates, err := chinese.CandidatesWithOptions(tt.input, &CandidateOptions{Fuzzy: tt.rule})
+		if err != nil || len(candidates) == 0 || candidates[0].Text != tt.first {
+			t.Errorf("CandidatesWithOptions(%q, %v) = %v, %v, expected %s first", tt.input, tt.rule, candidates, err, tt.first)
+		}
+		if !slices.ContainsFunc(candidates, func(candidate Candidate) bool { return candidate.Text == tt.contains }) {
+			t.Errorf("CandidatesWithOptions(%q, %v) missing %s", tt.input, tt.rule, tt.contains)
+		}
+	}
+	// 准确读音的单字排在更长的模糊音词之前
+	if candidates, _ := chinese.CandidatesWithOptions("yangsheng", &CandidateOptions{Fuzzy: FuzzyAll}); len(candidates) < 2 || candidates[1].Text != "样" {
+		t.Errorf("CandidatesWithOptions(yangsheng) = %v, expected 样 after the sentence", candidates)
+	}
+	if candidates, _ := chinese.Candidates("zongguo"); len(candidates) > 0 && candidates[0].Text == "中国" {
+		t.Errorf("Candidates(zongguo) = %v, expected no fuzzy match without options", candidates)
+	}
+	// 准确匹配排在模糊音匹配之前
+	if candidates, _ := chinese.CandidatesWithOptions("zong", &CandidateOptions{Fuzzy: FuzzyZZh}); len(candidates) == 0 || candidates[0].Text != "总" {
+		t.Errorf("CandidatesWithOptions(zong) = %v, expected 总 first", candidates)
+	}
+}
+
+func TestHomophones(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	tests := []struct {
+		name     string
+		word     string
+		options  *HomophoneOptions
+		contains string
+		match    HomophoneMatch
+		excludes string
+	}{
+		{name: "同音", word: "政府", contains: "政腐", match: HomophoneExact},
+		{name: "不同声调默认不包含", word: "牛奶", excludes: "妞奶"},
+		{name: "忽略声调", word: "牛奶", options: &HomophoneOptions{IgnoreTone: true}, contains: "妞奶", match: HomophoneToneDiff},
+		{name: "模糊音", word: "牛奶", options: &HomophoneOptions{Fuzzy: FuzzyNL}, contains: "流奶", match: HomophoneFuzzy},
+		{name: "多音字只按首选读音", word: "北京", excludes: "被京"},
+		{name: "多音字的其他读音声调不同", word: "北京", options: &HomophoneOptions{IgnoreTone: true}, contains: "被京", match: HomophoneToneDiff},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			homophones, err := chinese.Homophones(tt.word, tt.options)
+			if err != nil {
+				t.Fatalf("Homophones(%q) error = %v", tt.word, err)
+			}
+
+			found := map[string]Homophone{}
+			for i, homophone := range homophones {
+				if homophone.Text == tt.word {
+					t.Errorf("Homophones(%q) should not contain the word itself", tt.word)
+				}
+				if len(homophone.Pinyin) != 2 {
+					t.Errorf("Homophones(%q)[%d].Pinyin = %v", tt.word, i, homophone.Pinyin)
+				}
+				if i > 0 && homophone.Match < homophones[i-1].Match {
+					t.Errorf("Homophones(%q) not sorted by match", tt.word)
+				}
+				found[homophone.Text] = homophone
+			}
+
+			if tt.contains != "" {
+				if homophone, ok := found[tt.contains]; !ok || homophone.Match != tt.match {
+					t.Errorf("Homophones(%q)[%q] = %+v, %v, expected match %d", tt.word, tt.contains, homophone, ok, tt.match)
+				}
+			}
+			if _, ok := found[tt.excludes]; tt.excludes != "" && ok {
+				t.Errorf("Homophones(%q) should not contain %q", tt.word, tt.excludes)
+			}
+		})
+	}
+
+	homophones, _ := chinese.Homophones("政府", &HomophoneOptions{Limit: 5})
+	if len(homophones) != 5 {
+		t.Errorf("Homophones() with limit = %d results, expected 5", len(homophones))
+	}
+
+	// README 示例
+	homophones, _ = chinese.Homophones("政府", &HomophoneOptions{Limit: 3})
+	if len(homophones) == 0 || homophones[0].Text != "政腐" {
+		t.Errorf("Homophones(政府) = %+v, expected 政腐 first", homophones)
+	}
+	homophones, _ = chinese.Homophones("牛奶", &HomophoneOptions{Fuzzy: FuzzyNL})
+	if i := slices.IndexFunc(homophones, func(h Homophone) bool { return h.Match == HomophoneFuzzy }); i < 0 || homophones[i].Text != "流奶" {
+		t.Errorf("Homophones(牛奶) first fuzzy match at %d in %+v, expected 流奶", i, homophones)
+	}
+
+	// 数量上限较小时保留同音程度高的结果
+	homophones, _ = chinese.Homophones("牛奶", &HomophoneOptions{IgnoreTone: true, Fuzzy: FuzzyNL, Limit: 3})
+	for _, homophone := range homophones {
+		if homophone.Match != HomophoneExact {
+			t.Errorf("Homophones(牛奶) with limit 3 = %+v, expected only exact matches", homophones)
+			break
+		}
+	}
+	if len(homophones) != 3 {
+		t.Errorf("Homophones(牛奶) with limit 3 = %d results, expected 3", len(homophones))
+	}
+}
+
+func TestMatcher(t *testing.T) {
+	chinese := NewChineseWithFullData()
+	matcher := chinese.NewMatcher([]string{"北京", "北京大学", "南京", "重庆", "长沙", "我爱北京天安门", "Xi'an 西安"})
+
+	tests := []struct {
+		query    string
+		expected []MatchResult
+	}{
+		{"bj", []MatchResult{
+			{Index: 0, Text: "北京", Ranges: []MatchRange{{0, 2}}},
+			{Index: 1, Text: "北京大学", Ranges: []MatchRange{{0, 2}}},
+			{Index: 5, Text: "我爱北京天安门", Ranges: []MatchRange{{2, 4}}},
+		}},
+		{"beij", []MatchResult{
+			{Index: 0, Text: "北京", Ranges: []MatchRange{{0, 2}}},
+			{Index: 1, Text: "北京大学", Ranges: []MatchRange{{0, 2}}},
+			{Index: 5, Text: "我爱北京天安门", Ranges: []MatchRange{{2, 4}}},
+		}},
+		{"北jing", []MatchResult{
+			{Index: 0, Text: "北京", Ranges: []MatchRange{{0, 2}}},
+			{Index: 1, Text: "北京大学", Ranges: []MatchRange{{0, 2}}},
+			{Index: 5, Text: "我爱北京天安门", Ranges: []MatchRange{{2, 4}}},
+		}},
+		{"BJDX", []MatchResult{{Index: 1, Text: "北京大学", Ranges: []MatchRange{{0, 4}}}}},
+		// 多音字：重 zhòng / chóng
+		{"chongq", []MatchResult{{Index: 3, Text: "重庆", Ranges: []MatchRange{{0, 2}}}}},
+		{"zhongqing", []MatchResult{{Index: 3, Text: "重庆", Ranges: []MatchRange{{0, 2}}}}},
+		{"jing", []MatchResult{
+			{Index: 0, Text: "北京", Ranges: []MatchRange{{1, 2}}},
+			{Index: 1, Text: "北京大学", Ranges: []MatchRange{{1, 2}}},
+			{Index: 2, Text: "南京", Ranges: []MatchRange{{1, 2}}},
+			{Index: 5, Text: "我爱北京天安门", Ranges: []MatchRange{{3, 4}}},
+		}},
+		{"xi'an", []MatchResult{{Index: 6, Text: "Xi'an 西安", Ranges: []MatchRange{{0, 5}, {6, 8}}}}},
+		{"bei jing", []MatchResult{
+			{Index: 0, Text: "北京", Ranges: []MatchRange{{0, 2}}},
+			{Index: 1, Text: "北京大学", Ranges: []MatchRange{{0, 2}}},
+			{Index: 5, Text: "我爱北京天安门", Ranges: []MatchRange{{2, 4}}},
+		}},
+		{"shanghai", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results := matcher.Match(tt.query)
+			if fmt.Sprint(results) != fmt.Sprint(tt.expected) {
+				t.Errorf("Match(%q) = %v, expected %v", tt.query, results, tt.expected)
+			}
+		})
+	}
+
+	if ranges := chinese.PinyinMatch("tam", "我爱北京天安门"); fmt.Sprint(ranges) != "[{4 7}]" {
+		t.Errorf("PinyinMatch() = %v, expected [{4 7}]", ranges)
+	}
+	if ranges := chinese.PinyinMatch("tm", "我爱北京天安门"); ranges != nil {
+		t.Errorf("PinyinMatch() = %v, expected nil", ranges)
+	}
+
+	// l、n 后的 ü 可写作 v、u 或 ü
+	umlautTests := []struct {
+		query    string
+		target   string
+		expected string
+	}{
+		{"lvbu", "吕布", "[{0 2}]"},
+		{"lubu", "吕布", "[{0 2}]"},
+		{"lübu", "吕布", "[{0 2}]"},
+		{"nuer", "女儿", "[{0 2}]"},
+		{"lue", "略", "[{0 1}]"},
+	}
+	for _, tt := range umlautTests {
+		if ranges := chinese.PinyinMatch(tt.query, tt.target); fmt.Sprint(ranges) != tt.expected {
+			t.Errorf("PinyinMatch(%q, %q) = %v, expected %s", tt.query, tt.target, ranges, tt.expected)
+		}
+	}
+}
+
+func TestFuzzyPinyin(t *testing.T) {
+	chinese := NewChineseWithFullData()
+	rules := FuzzyNL | FuzzyZZh | FuzzyInIng
+
+	// 未启用模糊音
+	if chinese.PinyinEqual("lan", "nan", 0) {
+		t.Errorf("PinyinEqual(lan, nan, 0) = true, expected false")
+	}
+	// lüe、nüe 的 lue、nue 写法与 ü 写法相同
+	for _, pair := range [][2]string{{"lüe", "lue"}, {"nue4", "nüè"}, {"lve", "lue"}, {"lüe nüè", "lue4 nue4"}} {
+		if !chinese.PinyinEqual(pair[0], pair[1], 0) {
+			t.Errorf("PinyinEqual(%q, %q, 0) = false, expected true", pair[0], pair[1])
+		}
+	}
+	if chinese.PinyinEqual("lüè", "lue2", 0) {
+		t.Errorf("PinyinEqual(lüè, lue2, 0) = true, expected false")
+	}
+
+	chars, _ := chinese.PinyinToHanzi("lan")
+	if slices.Contains(chars, "男") {
+		t.Errorf("PinyinToHanzi(lan) should not contain 男 without fuzzy rules")
+	}
+
+	equalTests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"lan", "nan", true},
+		{"lán", "nan2", true},
+		{"lán", "nan3", false},
+		{"zong guo", "zhōng guó", true},
+		{"ping", "pin", true},
+		{"lü", "nv", true},
+		{"shi", "si", false}, // 未启用 s/sh
+		{"ang", "an", false}, // 未启用 an/ang
+		{"zhong", "zhong guo", false},
+	}
+	for _, tt := range equalTests {
+		if result := chinese.PinyinEqual(tt.a, tt.b, rules); result != tt.expected {
+			t.Errorf("PinyinEqual(%q, %q) = %v, expected %v", tt.a, tt.b, result, tt.expected)
+		}
+	}
+
+	chars, _ = chinese.PinyinToHanziWithOptions("lan", &HanziOptions{Fuzzy: rules})
+	if len(chars) == 0 || chars[0] != "兰" || !slices.Contains(chars, "男") {
+		t.Errorf("PinyinToHanziWithOptions(lan) = %v, expected 兰 first and containing 男", chars)
+	}
+
+	splits, _ := chinese.SplitPinyinWithOptions("tinshuo", &SplitOptions{Fuzzy: rules})
+	if !slices.ContainsFunc(splits, func(split []string) bool { return fmt.Sprint(split) == "[tin shuo]" }) {
+		t.Errorf("SplitPinyinWithOptions(tinshuo) = %v, expected to contain [tin shuo]", splits)
+	}
+	if splits, _ := chinese.SplitPinyinArray("tinshuo"); fmt.Sprint(splits) != "[[tinshuo]]" {
+		t.Errorf("SplitPinyinArray(tinshuo) = %v, expected [[tinshuo]] without fuzzy rules", splits)
+	}
+
+	matcher := chinese.NewMatcherWithOptions([]string{"男人", "中国"}, &MatcherOptions{Fuzzy: rules})
+	if results := matcher.Match("lanr"); len(results) != 1 || results[0].Text != "男人" {
+		t.Errorf("Match(lanr) = %v, expected 男人", results)
+	}
+	if results := matcher.Match("zongguo"); len(results) != 1 || results[0].Text != "中国" {
+		t.Errorf("Match(zongguo) = %v, expected 中国", results)
+	}
+	if results := chinese.NewMatcher([]string{"男人"}).Match("lanr"); len(results) != 0 {
+		t.Errorf("Match(lanr) = %v without fuzzy rules, expected none", results)
+	}
+}
+
+func TestCollator(t *testing.T) {
+	chinese := NewChineseWithFullData()
+	collator := chinese.NewCollator()
+
+	texts := []string{"张三", "Zoo", "银行", "马", "123", "妈妈", "重庆", "ma", "阿姨", "中国", "买", "行人", "中", "abc", "麻", "Ma"}
+	collator.SortStrings(texts)
+	expected := []string{"123", "阿姨", "abc", "重庆", "Ma", "ma", "麻", "马", "妈妈", "买", "行人", "银行", "张三", "中", "中国", "Zoo"}
+	if fmt.Sprint(texts) != fmt.Sprint(expected) {
+		t.Errorf("SortStrings() = %v, expected %v", texts, expected)
+	}
+
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"妈", "马", -1},   // 声调
+		{"马", "妈妈", -1},  // 音节少的在前
+		{"马", "买", -1},   // ma 在 mai 之前
+		{"重庆", "重要", -1}, // 词组读音 chóng / zhòng
+		{"银行", "行人", 1},  // háng 在 xíng 之前
+		{"中国", "中国", 0},
+		{"Apple", "北京", -1}, // 拉丁字母与拼音一起比较
+		{"北京", "car", -1},
+		{"!important", "abc", 1}, // 符号排在字母之后
+		{"#tag", "中国", 1},
+		{"Émile", "#tag", -1},
+		{"123", "!important", -1},
+		{"ma li", "mali", -1}, // 空白排在最前
+	}
+	for _, tt := range tests {
+		if result := collator.Compare(tt.a, tt.b); result != tt.expected {
+			t.Errorf("Compare(%q, %q) = %d, expected %d", tt.a, tt.b, result, tt.expected)
+		}
+		if result := bytes.Compare(collator.SortKey(tt.a), collator.SortKey(tt.b)); result != tt.expected {
+			t.Errorf("SortKey(%q) vs SortKey(%q) = %d, expected %d", tt.a, tt.b, result, tt.expected)
+		}
+	}
+}
+
+func TestGroupByLetter(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	tests := []struct {
+		name     string
+		texts    []string
+		options  *GroupOptions
+		expected string
+	}{
+		{
+			name:     "城市",
+			texts:    []string{"重庆", "北京", "长沙", "Amsterdam", "澳门", "上海", "123", "成都"},
+			expected: "[{A [Amsterdam 澳门]} {B [北京]} {C [长沙 成都 重庆]} {S [上海]} {# [123]}]",
+		},
+		{
+			name:     "姓名",
+			texts:    []string{"曾小贤", "张三", "单田芳", "区先生", "李四", "@me"},
+			options:  &GroupOptions{IsName: true, OtherLetter: "其他"},
+			expected: "[{L [李四]} {O [区先生]} {S [单田芳]} {Z [曾小贤 张三]} {其他 [@me]}]",
+		},
+		{
+			name:     "带附加符号的拉丁字母",
+			texts:    []string{"Émile", "éclair", "Zoë", "Ørsted", "Æsop", "ßeta"},
+			expected: "[{E [éclair Émile]} {O [Ørsted]} {Z [Zoë]} {# [ßeta Æsop]}]",
+		},
+		{
+			name:     "非姓名使用普通读音",
+			texts:    []string{"曾经", "单车"},
+			expected: "[{C [曾经]} {D [单车]}]",
+		},
+		{
+			name:     "空",
+			texts:    nil,
+			expected: "[]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sections := chinese.GroupByLetter(tt.texts, tt.options)
+			if result := fmt.Sprint(sections); result != tt.expected {
+				t.Errorf("GroupByLetter(%v) = %s, expected %s", tt.texts, result, tt.expected)
+			}
+		})
+	}
+
+	type contact struct {
+		name  string
+		phone string
+	}
+	contacts := []contact{{"王五", "3"}, {"Bob", "2"}, {"王二", "1"}}
+	sections := GroupByLetterFunc(chinese, contacts, func(c contact) string { return c.name }, &GroupOptions{IsName: true})
+	if result := fmt.Sprint(sections); result != "[{B [{Bob 2}]} {W [{王二 1} {王五 3}]}]" {
+		t.Errorf("GroupByLetterFunc() = %s", result)
+	}
+}
+
 func TestSplitPinyin(t *testing.T) {
 	chinese := NewChinese()
 
@@ -107,6 +1305,208 @@ func TestSplitPinyin(t *testing.T) {
 			}
 		})
 	}
+
+	// 未加载分词数据时使用内置音节表
+	for pinyin, expected := range map[string]string{"beijing": "[bei jing]", "xianggang": "[xiang gang]", "lve": "[lve]", "ng": "[ng]", "hm": "[hm]"} {
+		result, _ := chinese.SplitPinyinArray(pinyin)
+		if len(result) == 0 || fmt.Sprint(result[0]) != expected {
+			t.Errorf("NewChinese().SplitPinyinArray(%q) = %v, expected best %s", pinyin, result, expected)
+		}
+	}
+}
+
+func TestSplitPinyinSyllables(t *testing.T) {
+	fromFile := NewChinese()
+	if err := fromFile.LoadPinyinSplitData("data"); err != nil {
+		t.Fatalf("LoadPinyinSplitData() error = %v", err)
+	}
+
+	for name, chinese := range map[string]*Chinese{"嵌入数据": NewChineseWithFullData(), "数据文件": fromFile} {
+		tests := []struct {
+			pinyin   string
+			expected string
+		}{
+			{"beijing", "[bei jing]"},
+			{"xian", "[xian xi an]"},
+			{"xianggang", "[xiang gang xi ang gang]"},
+			{"shei", "[shei]"}, // 少见音节
+			{"dei", "[dei]"},
+			{"masheng", "[ma sheng]"}, // 数据中非汉语拼音的拼写（mas）被忽略
+			{"gilen", "[gilen]"},      // 数据中不在音节表中的拼写（gi、len）被忽略
+			{"rua", "[ru a]"},
+			{"lue", "[lue lu e]"},
+			{"ng", "[ng]"}, // 自成音节的鼻音只在单独成段时切分
+			{"hm", "[hm]"},
+			{"ng'ai", "[ng ai]"},
+			{"ńg", "[ńg]"},
+		}
+		for _, tt := range tests {
+			result, err := chinese.SplitPinyin(tt.pinyin)
+			if err != nil {
+				t.Fatalf("%s: SplitPinyin(%q) error = %v", name, tt.pinyin, err)
+			}
+			if fmt.Sprint(result) != tt.expected {
+				t.Errorf("%s: SplitPinyin(%q) = %v, expected %s", name, tt.pinyin, result, tt.expected)
+			}
+		}
+	}
+}
+
+func TestToneFromDataFile(t *testing.T) {
+	// 只从数据目录加载读音，不调用 LoadSoundData，声调处理使用内置带调字母表
+	chinese := NewChinese()
+	if err := chinese.LoadPinyinData("data"); err != nil {
+		t.Fatalf("LoadPinyinData() error = %v", err)
+	}
+
+	modes := []struct {
+		mode     ConvertMode
+		expected string
+	}{
+		{ModePinyinSoundNumber, "zhong1 guo2 lv4"},
+		{ModePinyinASCII, "zhong guo lv"},
+		{ModePinyin, "zhong guo lü"},
+	}
+	for _, tt := range modes {
+		result, err := chinese.ToPinyinString("中国绿", tt.mode, " ", false)
+		if err != nil {
+			t.Fatalf("ToPinyinString(%v) error = %v", tt.mode, err)
+		}
+		if result != tt.expected {
+			t.Errorf("ToPinyinString(%v) = %q, expected %q", tt.mode, result, tt.expected)
+		}
+	}
+
+	if zhuyin, err := chinese.PinyinToZhuyin("zhōng"); err != nil || zhuyin != "ㄓㄨㄥ" {
+		t.Errorf("PinyinToZhuyin(zhōng) = %q, %v, expected ㄓㄨㄥ", zhuyin, err)
+	}
+	if hanzi, err := chinese.PinyinToHanzi("zhong"); err != nil || !slices.Contains(hanzi, "中") {
+		t.Errorf("PinyinToHanzi(zhong) = %v, %v, expected to contain 中", hanzi, err)
+	}
+}
+
+func TestSplitPinyinWithOptions(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	tests := []struct {
+		name     string
+		pinyin   string
+		options  *SplitOptions
+		expected string
+	}{
+		{"音节少的在前", "xianggang", nil, "[[xiang gang] [xi ang gang]]"},
+		{"音节数相同按字频", "fangan", nil, "[[fang an] [fan gan]]"},
+		{"数量上限", "xianxian", &SplitOptions{Limit: 2}, "[[xian xian] [xi an xian]]"},
+		{"枚举所有方案", "xianxian", &SplitOptions{Exhaustive: true}, "[[xi an xi an] [xi an xian] [xian xi an] [xian xian]]"},
+		{"简拼", "beijdx", &SplitOptions{Abbreviation: true}, "[[bei j d x] [b ei j d x]]"},
+		{"未启用简拼", "bjdx", nil, "[[bjdx]]"},
+		{"无法切分", "abc1", nil, "[[abc1]]"},
+		{"空字符串", "", nil, "[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := chinese.SplitPinyinWithOptions(tt.pinyin, tt.options)
+			if err != nil {
+				t.Fatalf("SplitPinyinWithOptions(%q) error = %v", tt.pinyin, err)
+			}
+			if fmt.Sprint(result) != tt.expected {
+				t.Errorf("SplitPinyinWithOptions(%q) = %v, expected %s", tt.pinyin, result, tt.expected)
+			}
+		})
+	}
+
+	// 长输入不会指数增长
+	long := strings.Repeat("xian", 50)
+	result, _ := chinese.SplitPinyinWithOptions(long, nil)
+	if len(result) != DefaultSplitLimit || len(result[0]) != 50 {
+		t.Errorf("SplitPinyinWithOptions(long) = %d results, first has %d syllables", len(result), len(result[0]))
+	}
+}
+
+func TestSplitPinyinBoundariesAndTones(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	tests := []struct {
+		pinyin   string
+		expected string
+	}{
+		{"xi'an", "[[xi an]]"},
+		{"Xī'ān", "[[xī ān]]"},
+		{"xīān", "[[xī ān]]"}, // 一个音节最多带一个声调
+		{"xi1an1", "[[xi1 an1]]"},
+		{"xian1", "[[xian1] [xi an1]]"},
+		{"zhong guo", "[[zhong guo] [zhong gu o]]"},
+		{"Zhōngguó", "[[zhōng guó] [zhōng gu ó]]"},
+		{"lüe4", "[[lüe4] [lü e4]]"},
+		{"nü3 er2", "[[nü3 er2]]"},
+		{"xi’an", "[[xi an]]"},
+		{"zhong6", "[[zhong6]]"}, // 无效的数字声调
+		{"1xi", "[[1xi]]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pinyin, func(t *testing.T) {
+			result, err := chinese.SplitPinyinArray(tt.pinyin)
+			if err != nil {
+				t.Fatalf("SplitPinyinArray(%q) error = %v", tt.pinyin, err)
+			}
+			if fmt.Sprint(result) != tt.expected {
+				t.Errorf("SplitPinyinArray(%q) = %v, expected %s", tt.pinyin, result, tt.expected)
+			}
+		})
+	}
+
+	result, _ := chinese.SplitPinyin("xi'an")
+	if fmt.Sprint(result) != "[xi an]" {
+		t.Errorf("SplitPinyin(xi'an) = %v, expected [xi an]", result)
+	}
+}
+
+func TestSplitPinyinSpans(t *testing.T) {
+	chinese := NewChineseWithFullData()
+
+	// 片段格式: 类型:原文@起始-结束（0 拼音，1 字母，2 数字，3 其他）
+	format := func(spans []PinyinSpan) string {
+		parts := make([]string, len(spans))
+		for i, span := range spans {
+			parts[i] = fmt.Sprintf("%d:%s@%d-%d", span.Kind, span.Text, span.Start, span.End)
+		}
+		return strings.Join(parts, " ")
+	}
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"iphone13shouji", "1:iphone@0-6 2:13@6-8 0:shou@8-12 0:ji@12-14"},
+		{"abcxyz", "1:abcxyz@0-6"},
+		{"xiaomishouji", "0:xiao@0-4 0:mi@4-6 0:shou@6-10 0:ji@10-12"},
+		{"Xī'ān 2024", "0:Xī@0-3 0:ān@4-7 2:2024@8-12"},
+		{"xi1an1", "0:xi1@0-3 0:an1@3-6"},
+		{"woyaomaiiPhone!", "0:wo@0-2 0:yao@2-5 0:mai@5-8 1:iPhone@8-14 3:!@14-15"},
+		{"买 nikeshoes", "3:买@0-3 0:ni@4-6 0:ke@6-8 1:shoes@8-13"},
+		{"hello", "1:hello@0-5"},
+		{"İstanbul", "1:İstanbul@0-9"}, // 夹在剩余字母中间的音节不切分
+		{"beijingxyz", "0:bei@0-3 0:jing@3-7 1:xyz@7-10"},
+		{"a1b2", "0:a1@0-2 1:b@2-3 2:2@3-4"},
+		{"zhİzhongguo", "0:zhİ@0-4 0:zhong@4-9 0:guo@9-12"}, // İ 转小写后变短
+		{"\u212Aěxue", "0:\u212Aě@0-5 0:xue@5-8"},           // 开尔文符号 K 转小写后变短
+		{"ng5 hm", "0:ng5@0-3 0:hm@4-6"},                    // 自成音节的鼻音
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			result, err := chinese.SplitPinyinSpans(tt.text)
+			if err != nil {
+				t.Fatalf("SplitPinyinSpans(%q) error = %v", tt.text, err)
+			}
+			if format(result) != tt.expected {
+				t.Errorf("SplitPinyinSpans(%q) = %s, expected %s", tt.text, format(result), tt.expected)
+			}
+		})
+	}
 }
 
 func TestSplitPinyinArray(t *testing.T) {
@@ -0,0 +1,284 @@
+package zhkit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// zhuyinInitials 声母与注音符号对照
+var zhuyinInitials = map[string]string{
+	"b": "ㄅ", "p": "ㄆ", "m": "ㄇ", "f": "ㄈ",
+	"d": "ㄉ", "t": "ㄊ", "n": "ㄋ", "l": "ㄌ",
+	"g": "ㄍ", "k": "ㄎ", "h": "ㄏ",
+	"j": "ㄐ", "q": "ㄑ", "x": "ㄒ",
+	"zh": "ㄓ", "ch": "ㄔ", "sh": "ㄕ", "r": "ㄖ",
+	"z": "ㄗ", "c": "ㄘ", "s": "ㄙ",
+}
+
+// zhuyinFinals 韵母（标准写法，ü 记为 v）与注音符号对照
+// 空韵母对应 zhi、chi、shi、ri、zi、ci、si 中的 -i
+var zhuyinFinals = map[string]string{
+	"": "", "a": "ㄚ", "o": "ㄛ", "e": "ㄜ", "ê": "ㄝ",
+	"ai": "ㄞ", "ei": "ㄟ", "ao": "ㄠ", "ou": "ㄡ",
+	"an": "ㄢ", "en": "ㄣ", "ang": "ㄤ", "eng": "ㄥ", "ong": "ㄨㄥ", "er": "ㄦ",
+	"i": "ㄧ", "ia": "ㄧㄚ", "io": "ㄧㄛ", "ie": "ㄧㄝ", "iai": "ㄧㄞ", "iao": "ㄧㄠ", "iou": "ㄧㄡ",
+	"ian": "ㄧㄢ", "in": "ㄧㄣ", "iang": "ㄧㄤ", "ing": "ㄧㄥ", "iong": "ㄩㄥ",
+	"u": "ㄨ", "ua": "ㄨㄚ", "uo": "ㄨㄛ", "uai": "ㄨㄞ", "uei": "ㄨㄟ",
+	"uan": "ㄨㄢ", "uen": "ㄨㄣ", "uang": "ㄨㄤ", "ueng": "ㄨㄥ",
+	"v": "ㄩ", "ve": "ㄩㄝ", "van": "ㄩㄢ", "vn": "ㄩㄣ",
+	// 成音节鼻音
+	"m": "ㄇ", "n": "ㄋ", "ng": "ㄫ",
+}
+
+// zhuyinToneMarks 注音声调符号，下标即声调（一声不标）
+var zhuyinToneMarks = []string{"", "", "ˊ", "ˇ", "ˋ", "˙"}
+
+// zhuyinErhua 儿化韵尾
+const zhuyinErhua = "ㄦ"
+
+// splitSyllable 将无调拼音拆分为声母和标准写法的韵母
+// 还原 y、w 拼写以及 iu、ui、un、j/q/x 后的 u 等缩写，ü 记为 v
+// 无法识别时 ok 为 false
+func splitSyllable(base string) (initial, final string, ok bool) {
+	base = strings.ReplaceAll(strings.ToLower(base), "ü", "v")
+
+	for _, candidate := range pinyinInitials {
+		if strings.HasPrefix(base, candidate) && len(base) > len(candidate) && base != "ng" {
+			initial = candidate
+			break
+		}
+	}
+	final = base[len(initial):]
+
+	switch {
+	case initial == "" && strings.HasPrefix(final, "y"):
+		final = final[1:]
+		switch {
+		case strings.HasPrefix(final, "u"):
+			final = "v" + final[1:]
+		case !strings.HasPrefix(final, "i"):
+			final = "i" + final
+		}
+		if final == "iu" {
+			final = "iou"
+		}
+	case initial == "" && strings.HasPrefix(final, "w"):
+		final = final[1:]
+		if !strings.HasPrefix(final, "u") {
+			final = "u" + final
+		}
+	case initial == "j" || initial == "q" || initial == "x":
+		if strings.HasPrefix(final, "u") {
+			final = "v" + final[1:]
+		}
+	}
+
+	if initial != "" {
+		switch final {
+		case "iu":
+			final = "iou"
+		case "ui":
+			final = "uei"
+		case "un":
+			final = "uen"
+		}
+	}
+
+	// zhi、chi、shi、ri、zi、ci、si 的韵母记为空
+	if final == "i" && strings.ContainsAny(initial, "zcsr") {
+		final = ""
+	}
+
+	if _, exists := zhuyinFinals[final]; !exists {
+		return "", "", false
+	}
+	if final == "" && initial == "" {
+		return "", "", false
+	}
+	return initial, final, true
+}
+
+// joinSyllable 按拼写规则将声母和标准写法的韵母拼成无调拼音（ü 记为 v）
+func joinSyllable(initial, final string) string {
+	if final == "" {
+		return initial + "i"
+	}
+
+	if initial == "" {
+		switch {
+		case final == "i" || final == "in" || final == "ing":
+			return "y" + final
+		case final == "iou":
+			return "you"
+		case strings.HasPrefix(final, "i"):
+			return "y" + final[1:]
+		case final == "u":
+			return "wu"
+		case strings.HasPrefix(final, "u"):
+			return "w" + final[1:]
+		case strings.HasPrefix(final, "v"):
+			return "yu" + final[1:]
+		}
+		return final
+	}
+
+	switch final {
+	case "iou":
+		final = "iu"
+	case "uei":
+		final = "ui"
+	case "uen":
+		final = "un"
+	}
+	if (initial == "j" || initial == "q" || initial == "x") && strings.HasPrefix(final, "v") {
+		final = "u" + final[1:]
+	}
+	return initial + final
+}
+
+// syllableToZhuyin 将无调拼音和声调转换为注音
+func syllableToZhuyin(base string, tone int) (string, bool) {
+	erhua := false
+	if len(base) > 2 && strings.HasSuffix(base, "r") {
+		base, erhua = base[:len(base)-1], true
+	}
+
+	initial, final, ok := splitSyllable(base)
+	if !ok {
+		return "", false
+	}
+
+	zhuyin := zhuyinInitials[initial] + zhuyinFinals[final]
+	if tone == neutralTone {
+		zhuyin = zhuyinToneMarks[neutralTone] + zhuyin
+	} else if tone > 0 && tone < neutralTone {
+		zhuyin += zhuyinToneMarks[tone]
+	}
+	if erhua {
+		zhuyin += zhuyinErhua
+	}
+	return zhuyin, true
+}
+
+// toZhuyin 将带声调的拼音转换为注音，无法识别时返回原文
+func (c *Chinese) toZhuyin(pinyin string) string {
+	base, tone := c.splitTone(pinyin)
+	if zhuyin, ok := syllableToZhuyin(base, tone); ok {
+		return zhuyin
+	}
+	return pinyin
+}
+
+// PinyinToZhuyin 拼音转注音
+// 输入以空白分隔的音节，支持声调符号（zhōng）、数字声调（zhong1）和无调拼音
+// 无调拼音按一声输出
+func (c *Chinese) PinyinToZhuyin(pinyin string) (string, error) {
+	syllables := strings.Fields(pinyin)
+	results := make([]string, len(syllables))
+
+	for i, syllable := range syllables {
+		base, tone := c.splitTone(syllable)
+		if tone == neutralTone && !c.hasToneMark(syllable) {
+			tone = 1
+		}
+		zhuyin, ok := syllableToZhuyin(base, tone)
+		if !ok {
+			return "", fmt.Errorf("无法识别的拼音: %s", syllable)
+		}
+		results[i] = zhuyin
+	}
+
+	return strings.Join(results, " "), nil
+}
+
+// ZhuyinToPinyin 注音转拼音（带声调符号）
+// 输入以空白分隔的音节，如 "ㄓㄨㄥ ㄍㄨㄛˊ"
+func (c *Chinese) ZhuyinToPinyin(zhuyin string) (string, error) {
+	syllables := strings.Fields(zhuyin)
+	results := make([]string, len(syllables))
+
+	for i, syllable := range syllables {
+		pinyin, ok := c.zhuyinSyllableToPinyin(syllable)
+		if !ok {
+			return "", fmt.Errorf("无法识别的注音: %s", syllable)
+		}
+		results[i] = pinyin
+	}
+
+	return strings.Join(results, " "), nil
+}
+
+// zhuyinSyllableToPinyin 将一个注音音节转换为带声调符号的拼音
+func (c *Chinese) zhuyinSyllableToPinyin(syllable string) (string, bool) {
+	tone := 1
+	for t := 2; t < len(zhuyinToneMarks); t++ {
+		if strings.Contains(syllable, zhuyinToneMarks[t]) {
+			tone = t
+			syllable = strings.ReplaceAll(syllable, zhuyinToneMarks[t], "")
+		}
+	}
+
+	erhua := false
+	if syllable != zhuyinErhua && strings.HasSuffix(syllable, zhuyinErhua) {
+		syllable, erhua = strings.TrimSuffix(syllable, zhuyinErhua), true
+	}
+
+	initial := ""
+	for pinyin, symbol := range zhuyinInitials {
+		if strings.HasPrefix(syllable, symbol) && syllable != symbol || syllable == symbol && strings.ContainsAny(pinyin, "zcsr") {
+			initial = pinyin
+			syllable = strings.TrimPrefix(syllable, symbol)
+			break
+		}
+	}
+
+	final, found := "", syllable == ""
+	for pinyin, symbol := range zhuyinFinals {
+		if symbol == syllable && symbol != "" {
+			final, found = pinyin, true
+			// ㄨㄥ 在有声母时为 ong，零声母时为 ueng
+			if symbol == "ㄨㄥ" {
+				final = "ueng"
+				if initial != "" {
+					final = "ong"
+				}
+			}
+			break
+		}
+	}
+	if !found || (initial == "" && final == "") {
+		return "", false
+	}
+
+	base := joinSyllable(initial, final)
+	if initial == "" && (final == "m" || final == "n" || final == "ng") {
+		base = final
+	}
+	if erhua {
+		base += "r"
+	}
+	return c.markTone(base, tone), true
+}
+
+// hasToneMark 判断拼音中是否带有声调（声调符号或数字）
+func (c *Chinese) hasToneMark(pinyin string) bool {
+	for _, r := range pinyin {
+		if sound, exists := c.soundData[r]; exists && sound.tone > 0 {
+			return true
+		}
+		if combiningTone(r) > 0 || (r >= '1' && r <= '5') {
+			return true
+		}
+	}
+	return false
+}
+
+// PinyinToZhuyin 全局函数：拼音转注音
+func PinyinToZhuyin(pinyin string) (string, error) {
+	return defaultChinese.PinyinToZhuyin(pinyin)
+}
+
+// ZhuyinToPinyin 全局函数：注音转拼音
+func ZhuyinToPinyin(zhuyin string) (string, error) {
+	return defaultChinese.ZhuyinToPinyin(zhuyin)
+}